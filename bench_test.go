@@ -0,0 +1,197 @@
+package hint
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// allChecksConfig returns a config with every optional check enabled.
+func allChecksConfig() *Config {
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	v := reflect.ValueOf(config).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Kind() == reflect.Bool && v.Field(i).CanSet() {
+			v.Field(i).SetBool(true)
+		}
+	}
+	// These change how the linter runs rather than enable checks.
+	config.UseThis = false
+	config.DeclOrder = false
+	config.TypeCheck = false
+	config.BuildConstraints = false
+	return config
+}
+
+// largeFile returns the source of a file with n copies of a chunk of typical code.
+func largeFile(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("// Package foo does things.\npackage foo\n\nimport (\n\t\"errors\"\n\t\"fmt\"\n)\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, `// T%[1]d is a type.
+type T%[1]d struct {
+	name string
+	ids  []int
+}
+
+var errNotFound%[1]d = errors.New("not found")
+
+// Lookup%[1]d looks things up.
+func (t *T%[1]d) Lookup%[1]d(m map[string]int, key string) (int, error) {
+	var count int = 0
+	for k, v := range m {
+		if k == key {
+			count += 1
+			return v, nil
+		} else {
+			t.ids = append(t.ids, v)
+		}
+	}
+	switch count {
+	case 0:
+		return 0, errNotFound%[1]d
+	case 1, 2:
+		return count, fmt.Errorf("found %%d times", count)
+	}
+	go func() {
+		fmt.Println(t.name)
+	}()
+	return 0, nil
+}
+
+`, i)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkLintLargeFile(b *testing.B) {
+	src := largeFile(500)
+	config := allChecksConfig()
+	l := new(Linter)
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := l.Lint("large.go", config, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// manyNames returns the source of a file declaring n groups of identifiers
+// in a mix of styles.
+func manyNames(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("package foo\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&buf, `func handleRequest%[1]d(requestURL string, userID int) (responseBody []byte, httpStatus int) {
+	var serverName, clientIp, maxRetryCount, x, jsonData, apiKeyValue%[1]d string
+	for idx, someValue := range responseBody {
+		localCounter := idx + int(someValue)
+		_ = localCounter
+	}
+	return
+}
+
+`, i)
+	}
+	return buf.Bytes()
+}
+
+func BenchmarkLintNames(b *testing.B) {
+	src := manyNames(1000)
+	config := NewDefaultConfig()
+	l := new(Linter)
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := l.Lint("names.go", config, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFixName(b *testing.B) {
+	config := NewDefaultConfig()
+	f := &file{pkg: &pkg{initialisms: upperSet(config.Initialisms)}, config: config}
+	names := []string{"requestURL", "userID", "serverName", "clientIp", "maxRetryCount", "x", "jsonData", "apiKeyValue", "some_thing", "HTTPServer"}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, name := range names {
+			f.fixName(name)
+		}
+	}
+}
+
+// BenchmarkLintFiltered lints a file where most problems are below MinConfidence.
+func BenchmarkLintFiltered(b *testing.B) {
+	src := largeFile(500)
+	config := allChecksConfig()
+	config.MinConfidence = 1
+	l := new(Linter)
+	b.SetBytes(int64(len(src)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := l.Lint("large.go", config, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLintDir lints a tree of 1,000 small files in 100 packages.
+func BenchmarkLintDir(b *testing.B) {
+	dir := writeDirTree(b)
+	defer os.RemoveAll(dir)
+	config := NewDefaultConfig()
+	l := new(Linter)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := l.LintDir(dir, config); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkLintDirConcurrency lints the tree of BenchmarkLintDir with
+// different numbers of packages linted in parallel.
+func BenchmarkLintDirConcurrency(b *testing.B) {
+	dir := writeDirTree(b)
+	defer os.RemoveAll(dir)
+	for _, n := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			config := NewDefaultConfig()
+			config.Concurrency = n
+			l := new(Linter)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := l.LintDir(dir, config); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// writeDirTree writes a tree of 1,000 small files in 100 packages
+// and returns its root.
+func writeDirTree(b *testing.B) string {
+	files := make(map[string]string)
+	for i := 0; i < 1000; i++ {
+		files[fmt.Sprintf("p%d/f%d.go", i/10, i)] = fmt.Sprintf(`// Package p does things.
+package p
+
+// F%[1]d does a thing.
+func F%[1]d(n int) (int, error) {
+	var total int = 0
+	for i := 0; i < n; i++ {
+		total += i
+	}
+	return total, nil
+}
+`, i)
+	}
+	return writeTree(b, files)
+}
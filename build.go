@@ -0,0 +1,22 @@
+package hint
+
+import (
+	"bytes"
+	"go/build"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// matchBuildContext reports whether the file with the given name and source
+// would be included in a build for ctx. It evaluates GOOS/GOARCH file name
+// suffixes as well as //go:build and // +build constraints.
+// Files without constraints always match.
+func matchBuildContext(ctx *build.Context, filename string, src []byte) (bool, error) {
+	c := *ctx
+	c.OpenFile = func(string) (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(src)), nil
+	}
+	dir, name := filepath.Split(filename)
+	return c.MatchFile(dir, name)
+}
@@ -0,0 +1,50 @@
+package hint
+
+import (
+	"go/build"
+	"sort"
+	"testing"
+)
+
+func TestBuildConstraints(t *testing.T) {
+	// Each file has a badly named var, so problems are reported
+	// for exactly the files that are linted.
+	const body = "package foo\n\nvar bad_name int\n"
+	files := map[string][]byte{
+		"plain.go":      []byte(body),
+		"os_windows.go": []byte(body),
+		"arch_arm64.go": []byte(body),
+		"tagged.go":     []byte("//go:build windows\n\n" + body),
+		"old_tagged.go": []byte("// +build windows\n\n" + body),
+		"linux_only.go": []byte("//go:build linux && amd64\n\n" + body),
+		"os_linux.go":   []byte(body),
+		"negated.go":    []byte("//go:build !windows\n\n" + body),
+	}
+	ctx := build.Default
+	ctx.GOOS, ctx.GOARCH = "linux", "amd64"
+
+	config := NewDefaultConfig()
+	config.Package = false
+	config.BuildConstraints = true
+	config.BuildContext = &ctx
+
+	ps, err := new(Linter).LintFiles(files, config)
+	if err != nil {
+		t.Fatalf("LintFiles: %v", err)
+	}
+	var got []string
+	for _, p := range ps {
+		got = append(got, p.File)
+	}
+	sort.Strings(got)
+	want := []string{"linux_only.go", "negated.go", "os_linux.go", "plain.go"}
+	if len(got) != len(want) {
+		t.Fatalf("problems reported for %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("problems reported for %q, want %q", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package hint
+
+import (
+	"go/ast"
+	"go/token"
+	"hash/fnv"
+	"sync"
+)
+
+// A Cache holds parsed files by the hash of their source, so linting the
+// same source again, as an editor does on every keystroke, doesn't parse it
+// again. See Linter.LintCached. It holds up to a fixed number of files,
+// dropping the least recently used ones. It is safe for concurrent use.
+type Cache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[uint64]*cacheEntry
+	clock   uint64 // incremented on each use of an entry
+}
+
+type cacheEntry struct {
+	src  string // to tell hash collisions apart
+	fset *token.FileSet
+	f    *ast.File
+	used uint64
+}
+
+// NewCache returns a cache holding up to max parsed files.
+// If max is less than 1, it holds one.
+func NewCache(max int) *Cache {
+	if max < 1 {
+		max = 1
+	}
+	return &Cache{max: max, entries: make(map[uint64]*cacheEntry)}
+}
+
+// Get returns the file parsed from src and its file set, if in the cache.
+// The file must not be modified.
+func (c *Cache) Get(src []byte) (*token.FileSet, *ast.File, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[hashSource(src)]
+	if !ok || e.src != string(src) {
+		return nil, nil, false
+	}
+	c.clock++
+	e.used = c.clock
+	return e.fset, e.f, true
+}
+
+// Put adds f, parsed from src into fset, to the cache.
+func (c *Cache) Put(src []byte, fset *token.FileSet, f *ast.File) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h := hashSource(src)
+	if _, ok := c.entries[h]; !ok && len(c.entries) >= c.max {
+		var oldest uint64
+		var used uint64
+		for k, e := range c.entries {
+			if used == 0 || e.used < used {
+				oldest, used = k, e.used
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.clock++
+	c.entries[h] = &cacheEntry{src: string(src), fset: fset, f: f, used: c.clock}
+}
+
+// Len returns the number of files in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// hashSource returns the FNV-1a hash of src.
+func hashSource(src []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(src)
+	return h.Sum64()
+}
@@ -0,0 +1,49 @@
+package hint
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLintCached(t *testing.T) {
+	src := []byte("package foo\n\nvar a_b int = 0\n")
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	l := new(Linter)
+	want, err := l.Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewCache(2)
+	for i := 0; i < 2; i++ {
+		got, err := l.LintCached("foo.go", config, src, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("run %d: LintCached returned %v, Lint returned %v", i, got, want)
+		}
+	}
+	_, f, ok := cache.Get(src)
+	if !ok {
+		t.Fatal("source not in cache after LintCached")
+	}
+
+	if _, err := l.LintCached("bad.go", config, []byte("package"), cache); err == nil {
+		t.Error("LintCached of invalid source: got nil error")
+	}
+	// The entry for src is used last, so a new one evicts the other.
+	bar := []byte("package bar\n")
+	cache.Put(bar, nil, nil)
+	cache.Get(src)
+	cache.Put([]byte("package baz\n"), nil, nil)
+	if _, g, ok := cache.Get(src); !ok || g != f {
+		t.Error("most recently used entry was evicted")
+	}
+	if _, _, ok := cache.Get(bar); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	if n := cache.Len(); n != 2 {
+		t.Errorf("cache holds %d files, want 2", n)
+	}
+}
@@ -0,0 +1,99 @@
+package hint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// An Analysis describes the file a custom check runs on.
+// The fields must not be modified.
+type Analysis struct {
+	Fset     *token.FileSet
+	File     *ast.File
+	Filename string
+	Src      []byte
+	Config   *Config
+
+	// Decls are the top-level declarations of File the check should examine.
+	// By default they are all of them, but with Config.DeclOrder the check
+	// runs once per declaration, with only that one, and once with none
+	// for the package clause.
+	Decls []ast.Decl
+
+	name string
+	f    *file
+}
+
+// IsTest reports whether the file is a test file.
+func (a *Analysis) IsTest() bool { return a.f.isTest() }
+
+// TypeOf returns the type of expr, or nil if it is not known.
+// Types are only known if Config.TypeCheck is set.
+func (a *Analysis) TypeOf(expr ast.Expr) types.Type { return a.f.typeOf(expr) }
+
+// Reportf reports a problem at node with the given confidence, in (0,1].
+// Its category is the name of the check. Like the problems of the built-in
+// checks, it is dropped if its confidence is below Config.MinConfidence,
+// or Config.CategoryMinConfidence for the check.
+func (a *Analysis) Reportf(node ast.Node, confidence float64, format string, args ...interface{}) {
+	a.f.errorf(node, confidence, append([]interface{}{category(a.name), format}, args...)...)
+}
+
+type customCheck struct {
+	name string
+	run  func(*Analysis)
+}
+
+// customChecks are the checks registered with RegisterCheck, in order.
+var customChecks []customCheck
+
+// RegisterCheck registers a custom check, such as an organization's own
+// rules, to be run on every file after the built-in checks, in the order
+// the checks were registered. It is meant to be called from an init function
+// and must not be called while linting. It panics if name is empty or
+// already registered.
+//
+// The Analysis passed to run is only valid during the call.
+// The fields of Analysis and the behavior of its methods described here
+// won't change in incompatible ways; new fields and methods may be added.
+func RegisterCheck(name string, run func(*Analysis)) {
+	if name == "" {
+		panic("hint: RegisterCheck with empty name")
+	}
+	for _, c := range customChecks {
+		if c.name == name {
+			panic("hint: RegisterCheck called twice for check " + name)
+		}
+	}
+	customChecks = append(customChecks, customCheck{name, run})
+}
+
+// runCustomChecks runs the checks registered with RegisterCheck.
+func (f *file) runCustomChecks() {
+	for _, c := range customChecks {
+		c.run(&Analysis{
+			Fset:     f.fset,
+			File:     f.f,
+			Filename: f.filename,
+			Src:      f.src,
+			Config:   f.config,
+			Decls:    f.decls,
+			name:     c.name,
+			f:        f,
+		})
+	}
+}
+
+// Walk traverses the AST rooted at node in depth-first order, like ast.Inspect,
+// calling fn for each node. fn returns whether Walk should proceed into the
+// children of the node. Unlike with ast.Inspect, fn is never called with nil.
+// The built-in checks visit nodes the same way: Walk uses their dispatcher.
+func Walk(node ast.Node, fn func(ast.Node) bool) {
+	f := new(file)
+	f.visit(fn)
+	d := f.dispatcher
+	ast.Walk(d, node)
+	d.reset()
+	dispatchers.Put(d)
+}
@@ -0,0 +1,122 @@
+package hint
+
+import (
+	"go/ast"
+	"strings"
+	"testing"
+)
+
+// withChecks runs fn with only the given custom checks registered.
+func withChecks(checks map[string]func(*Analysis), fn func()) {
+	saved := customChecks
+	defer func() { customChecks = saved }()
+	customChecks = nil
+	for name, run := range checks {
+		RegisterCheck(name, run)
+	}
+	fn()
+}
+
+func TestRegisterCheck(t *testing.T) {
+	src := []byte(`// Package foo ...
+package foo
+
+func legacyThing() {}
+
+func other() {
+	legacyCall()
+}
+`)
+	legacy := func(a *Analysis) {
+		for _, decl := range a.Decls {
+			Walk(decl, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && strings.HasPrefix(id.Name, "legacy") {
+					a.Reportf(id, 0.7, "%s uses a legacy name", id.Name)
+				}
+				return true
+			})
+		}
+	}
+	for _, declOrder := range []bool{false, true} {
+		withChecks(map[string]func(*Analysis){"legacy-names": legacy}, func() {
+			config := NewDefaultConfig()
+			config.MinConfidence = 0.5
+			config.DeclOrder = declOrder
+			ps, err := new(Linter).Lint("foo.go", config, src)
+			if err != nil {
+				t.Fatalf("Lint: %v", err)
+			}
+			var got []string
+			for _, p := range ps {
+				if p.Category != "legacy-names" {
+					t.Errorf("DeclOrder=%v: unexpected problem %q in category %q", declOrder, p.Text, p.Category)
+					continue
+				}
+				got = append(got, p.Text)
+			}
+			want := "legacyThing uses a legacy name, legacyCall uses a legacy name"
+			if strings.Join(got, ", ") != want {
+				t.Errorf("DeclOrder=%v: got problems %q, want %q", declOrder, got, want)
+			}
+
+			config.CategoryMinConfidence = map[string]float64{"legacy-names": 0.8}
+			ps, err = new(Linter).Lint("foo.go", config, src)
+			if err != nil {
+				t.Fatalf("Lint: %v", err)
+			}
+			if len(ps) != 0 {
+				t.Errorf("DeclOrder=%v: got %d problems below the category threshold, want none", declOrder, len(ps))
+			}
+		})
+	}
+}
+
+func TestRegisterCheckPanics(t *testing.T) {
+	noop := func(*Analysis) {}
+	for _, names := range [][]string{{""}, {"a", "a"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterCheck(%q) didn't panic", names)
+				}
+			}()
+			withChecks(nil, func() {
+				for _, name := range names {
+					RegisterCheck(name, noop)
+				}
+			})
+		}()
+	}
+}
+
+func TestWalk(t *testing.T) {
+	src := []byte(`package foo
+
+func f() {
+	g(func() { h() })
+}
+`)
+	config := NewDefaultConfig()
+	pkg, err := newPkg(map[string][]byte{"foo.go": src}, config)
+	if err != nil {
+		t.Fatalf("newPkg: %v", err)
+	}
+	var calls []string
+	for _, f := range pkg.files {
+		Walk(f.f, func(n ast.Node) bool {
+			if n == nil {
+				t.Fatal("Walk called fn with nil")
+			}
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false
+			}
+			if ce, ok := n.(*ast.CallExpr); ok {
+				calls = append(calls, ce.Fun.(*ast.Ident).Name)
+			}
+			return true
+		})
+	}
+	if got := strings.Join(calls, ","); got != "g" {
+		t.Errorf("Walk visited calls %q, want only g", got)
+	}
+}
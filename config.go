@@ -3,7 +3,10 @@ package hint
 import (
 	"encoding/json"
 	"fmt"
+	"go/build"
 	"io/ioutil"
+	"regexp"
+	"strings"
 )
 
 var defaultCommonInitialisms = map[string]bool{
@@ -38,6 +41,12 @@ var defaultCommonInitialisms = map[string]bool{
 	"XML":   true,
 }
 
+const defaultSecretNamePattern = `(?i)(passw(or)?d|secret|token|api_?key)`
+
+var defaultSecretPlaceholders = []string{"changeme", "placeholder", "xxx", "todo"}
+
+var defaultNoCopyTypes = []string{"sync.Mutex", "sync.RWMutex", "sync.WaitGroup", "sync.Once", "sync.Cond", "sync/atomic.Value"}
+
 var defaultBadReceiverNames = map[string]bool{
 	"me":   true,
 	"this": true,
@@ -46,21 +55,203 @@ var defaultBadReceiverNames = map[string]bool{
 
 // Config defines configuration options for linter
 type Config struct {
-	Package            bool `json:"package"`
-	Imports            bool `json:"imports"`
-	Names              bool `json:"names"`
-	Exported           bool `json:"exported"`
-	VarDecls           bool `json:"var-decls"`
-	Elses              bool `json:"elses"`
-	MakeSlice          bool `json:"make-slice"`
-	ErrorReturn        bool `json:"error-return"`
-	IgnoredReturn      bool `json:"ignored-return"`
-	PackageUnderscore  bool `json:"package-underscore"`
-	NamedReturn        bool `json:"named-return"`
-	PackagePrefixNames bool `json:"package-prefix-names"`
-	UseThis            bool `json:"use-this"`
+	Package                  bool `json:"package"`
+	Imports                  bool `json:"imports"`
+	Names                    bool `json:"names"`
+	Exported                 bool `json:"exported"`
+	VarDecls                 bool `json:"var-decls"`
+	Elses                    bool `json:"elses"`
+	MakeSlice                bool `json:"make-slice"`
+	ErrorReturn              bool `json:"error-return"`
+	IgnoredReturn            bool `json:"ignored-return"`
+	PackageUnderscore        bool `json:"package-underscore"`
+	NamedReturn              bool `json:"named-return"`
+	PackagePrefixNames       bool `json:"package-prefix-names"`
+	UseThis                  bool `json:"use-this"`
+	LoopVarCapture           bool `json:"loop-var-capture"`
+	HardcodedSecret          bool `json:"hardcoded-secret"`
+	InsecureRand             bool `json:"insecure-rand"`
+	Deprecated               bool `json:"deprecated"`
+	TestSignatures           bool `json:"test-signatures"`
+	WeakHash                 bool `json:"weak-hash"`
+	InsecureTLS              bool `json:"insecure-tls"`
+	ExecCommand              bool `json:"exec-command"`
+	FilepathJoin             bool `json:"filepath-join"`
+	UselessSprintf           bool `json:"useless-sprintf"`
+	FilePermissions          bool `json:"file-permissions"`
+	GetterNames              bool `json:"getter-names"`
+	ConcreteErrorReturn      bool `json:"concrete-error-return"`
+	SubtestT                 bool `json:"subtest-t"`
+	TableTest                bool `json:"table-test"`
+	CommentSpacing           bool `json:"comment-spacing"`
+	DuplicateCase            bool `json:"duplicate-case"`
+	ExportedSentinels        bool `json:"exported-sentinels"`
+	UnexportedSentinel       bool `json:"unexported-sentinel"`
+	BlankLines               bool `json:"blank-lines"`
+	DeadMake                 bool `json:"dead-make"`
+	UnusedReceiver           bool `json:"unused-receiver"`
+	BuiltinShadow            bool `json:"builtin-shadow"`
+	ShadowBuiltin            bool `json:"shadow-builtin"`
+	ParamNameEqualsType      bool `json:"param-name-equals-type"`
+	LargeParamCopy           bool `json:"large-param-copy"`
+	InlineConstraint         bool `json:"inline-constraint"`
+	BuiltinPrint             bool `json:"builtin-print"`
+	NoGlobalVars             bool `json:"no-global-vars"`
+	EmptyErrorHandler        bool `json:"empty-error-handler"`
+	SprintfKey               bool `json:"sprintf-key"`
+	RedundantConversion      bool `json:"redundant-conversion"`
+	DoubleMapLookup          bool `json:"double-map-lookup"`
+	ConstIndexRange          bool `json:"const-index-range"`
+	MapRangeOrder            bool `json:"map-range-order"`
+	RedundantNamedConversion bool `json:"redundant-named-conversion"`
+	NestedAppend             bool `json:"nested-append"`
+	DeferCloseNamedErr       bool `json:"defer-close-named-err"`
+	MagicNumbers             bool `json:"magic-numbers"`
+	Init                     bool `json:"init"`
+	RequireExamples          bool `json:"require-examples"`
+	UselessWrap              bool `json:"useless-wrap"`
+	SliceEquality            bool `json:"slice-equality"`
+	NoCopy                   bool `json:"no-copy"`
+	LenCheck                 bool `json:"len-check"`
+	EmbeddedMutex            bool `json:"embedded-mutex"`
+	MapStructAppend          bool `json:"map-struct-append"`
+	ShortVarDecl             bool `json:"short-var-decl"`
+	PredicateNaming          bool `json:"predicate-naming"`
+	RawString                bool `json:"raw-string"`
+	WrapNonError             bool `json:"wrap-non-error"`
+	UnusedContext            bool `json:"unused-context"`
+	RepeatedCleanup          bool `json:"repeated-cleanup"`
+	BreakInSwitch            bool `json:"break-in-switch"`
+	RedundantContinue        bool `json:"redundant-continue"`
+	TrailingBreak            bool `json:"trailing-break"`
+	ErrorVerb                bool `json:"error-verb"`
+	UseBeforeErrCheck        bool `json:"use-before-err-check"`
+	EnumStringer             bool `json:"enum-stringer"`
+	MissingNewline           bool `json:"missing-newline"`
+	ReturnInterface          bool `json:"return-interface"`
+	RedundantLabel           bool `json:"redundant-label"`
+	DuplicateConstValue      bool `json:"duplicate-const-value"`
+	UnusedError              bool `json:"unused-error"`
+	HandlerSignature         bool `json:"handler-signature"`
+	ThinWrapper              bool `json:"thin-wrapper"`
+	PanicControlFlow         bool `json:"panic-control-flow"`
 
 	MinConfidence float64 `json:"min-confidence"`
+	// CategoryMinConfidence overrides MinConfidence for the problems
+	// of the given categories, such as "naming" or "performance".
+	CategoryMinConfidence map[string]float64 `json:"category-min-confidence"`
+
+	// GoVersion is the Go version targeted by the linted code, e.g. "1.21".
+	// Empty means unknown; checks that depend on it assume older semantics.
+	GoVersion string `json:"go-version"`
+
+	// DeclOrder makes the linter run all checks on one top-level declaration
+	// at a time, so problems are reported in source order as they are found
+	// instead of grouped by check. It keeps memory use down on very large files.
+	DeclOrder bool `json:"decl-order"`
+
+	// SortProblems sorts the problems of each file by position instead of
+	// reporting them grouped by check. SortByConfidence sorts them by
+	// confidence, highest first, and then by position, like golint does.
+	// Only one of them can be set; with DeclOrder they sort the problems of
+	// each declaration, which are already in position order.
+	SortProblems     bool `json:"sort-problems"`
+	SortByConfidence bool `json:"sort-by-confidence"`
+
+	// TypeCheck enables type checking of the linted package. Some checks
+	// are more precise with type information, but it is slower.
+	TypeCheck bool `json:"type-check"`
+
+	// BuildConstraints makes LintFiles and LintDir skip files that are
+	// excluded from the build by their name or build constraints.
+	BuildConstraints bool `json:"build-constraints"`
+	// BuildContext is the context build constraints are evaluated against.
+	// If nil, build.Default (the current GOOS/GOARCH) is used.
+	BuildContext *build.Context `json:"-"`
+
+	// NormalizePaths makes Problem.File use forward slashes as separators,
+	// whatever the OS and however the filename was passed in.
+	NormalizePaths bool `json:"normalize-paths"`
+
+	// IgnorePatterns are patterns of paths LintDir skips, in addition to
+	// those in a .hintignore file in the linted directory. They are
+	// gitignore-style globs, like "gen/" or "*_string.go".
+	IgnorePatterns []string `json:"ignore-patterns"`
+
+	// Concurrency is the number of packages LintDir lints in parallel,
+	// or 0 for runtime.GOMAXPROCS(0).
+	Concurrency int `json:"concurrency"`
+
+	// MaxBlankLines is the number of consecutive blank lines allowed
+	// in a function body by the BlankLines check.
+	MaxBlankLines int `json:"max-blank-lines"`
+
+	// MaxResults is the number of results allowed for a function, or 0 for no limit.
+	// With MaxResultsIgnoreError an error as the last result doesn't count.
+	MaxResults            int  `json:"max-results"`
+	MaxResultsIgnoreError bool `json:"max-results-ignore-error"`
+
+	// MaxTypeParams is the number of type parameters allowed for a generic
+	// function or type, or 0 for no limit.
+	MaxTypeParams int `json:"max-type-params"`
+
+	// MaxSwitchCases is the number of cases allowed for a switch that only
+	// maps values to values before suggesting a map, or 0 for no limit.
+	MaxSwitchCases int `json:"max-switch-cases"`
+
+	// LargeParamFields is the number of fields above which the LargeParamCopy
+	// check considers a struct too large to pass by value.
+	LargeParamFields int `json:"large-param-fields"`
+
+	// AllowedMagicNumbers are numbers, like "10" or "0.5", that the MagicNumbers
+	// check accepts besides 0, 1, 2 and -1. With MagicNumbersDurations it
+	// also complains about numbers multiplied by a time unit, like 5 * time.Second.
+	AllowedMagicNumbers   []string `json:"allowed-magic-numbers"`
+	MagicNumbersDurations bool     `json:"magic-numbers-durations"`
+
+	// MaxInitStatements is the number of top-level statements allowed in
+	// an init function by the Init check.
+	MaxInitStatements int `json:"max-init-statements"`
+
+	// ExamplesMinSymbols is the number of exported functions and types
+	// a package needs for the RequireExamples check to apply to it.
+	ExamplesMinSymbols int `json:"examples-min-symbols"`
+
+	// NoCopyTypes are the types, as import path and name like
+	// "sync/atomic.Value", that the NoCopy check doesn't allow to be copied.
+	NoCopyTypes []string `json:"no-copy-types"`
+
+	// RawStringMinEscapes is the number of escaped backslashes and quotes
+	// from which the RawString check suggests a raw string.
+	RawStringMinEscapes int `json:"raw-string-min-escapes"`
+
+	// SecretNamePattern is a regular expression matching names of variables
+	// and constants that are expected to hold credentials.
+	SecretNamePattern string `json:"secret-name-pattern"`
+	// SecretPlaceholders lists values that are obviously not real secrets.
+	SecretPlaceholders []string `json:"secret-placeholders"`
+
+	// AllowedUnderscoreNames and AllowedNameRegexps exempt names,
+	// such as those of generated code, from the underscore and initialism
+	// rules of the Names check. A regexp can match any part of a name;
+	// use ^ and $ to match whole names.
+	AllowedUnderscoreNames map[string]bool `json:"allowed-underscore-names"`
+	AllowedNameRegexps     []string        `json:"allowed-name-regexps"`
+
+	// ErrorTypeNames lists more types, such as "os.PathError", that the
+	// ErrorReturn check treats as errors. A leading "*" is ignored.
+	ErrorTypeNames []string `json:"error-type-names"`
+
+	// ErrorVarNamingStrict makes the err/Err prefix rule for package-level
+	// error variables also apply to those declared with an error type, see
+	// ErrorTypeNames, or initialized with a composite literal of one,
+	// not only to those initialized with errors.New or fmt.Errorf.
+	ErrorVarNamingStrict bool `json:"error-var-naming-strict"`
+
+	// RequireEachConstDoc makes the doc comment check require a doc or line
+	// comment on each exported constant of a const block, such as the values
+	// of an enum, instead of accepting a comment on the block.
+	RequireEachConstDoc bool `json:"require-each-const-doc"`
 
 	IgnoreFiles    []string `json:"ignore-files"`
 	ignoreFilesMap map[string]bool
@@ -78,23 +269,98 @@ type Config struct {
 // NewDefaultConfig creates linter config with predefined options
 func NewDefaultConfig() *Config {
 	return &Config{
-		Package:            true,
-		Imports:            true,
-		Names:              true,
-		Exported:           true,
-		VarDecls:           true,
-		Elses:              true,
-		MakeSlice:          true,
-		ErrorReturn:        true,
-		IgnoredReturn:      true,
-		PackageUnderscore:  true,
-		NamedReturn:        false,
-		PackagePrefixNames: false,
-		UseThis:            false,
-
-		MinConfidence:    0.8,
-		Initialisms:      defaultCommonInitialisms,
-		BadReceiverNames: defaultBadReceiverNames,
+		Package:                  true,
+		Imports:                  true,
+		Names:                    true,
+		Exported:                 true,
+		VarDecls:                 true,
+		Elses:                    true,
+		MakeSlice:                true,
+		ErrorReturn:              true,
+		IgnoredReturn:            true,
+		PackageUnderscore:        true,
+		NamedReturn:              false,
+		PackagePrefixNames:       false,
+		UseThis:                  false,
+		LoopVarCapture:           false,
+		HardcodedSecret:          false,
+		InsecureRand:             false,
+		Deprecated:               false,
+		TestSignatures:           false,
+		WeakHash:                 false,
+		InsecureTLS:              false,
+		ExecCommand:              false,
+		FilepathJoin:             false,
+		UselessSprintf:           false,
+		FilePermissions:          false,
+		GetterNames:              false,
+		ConcreteErrorReturn:      false,
+		SubtestT:                 false,
+		TableTest:                false,
+		CommentSpacing:           false,
+		DuplicateCase:            false,
+		ExportedSentinels:        false,
+		UnexportedSentinel:       false,
+		BlankLines:               false,
+		DeadMake:                 false,
+		UnusedReceiver:           false,
+		BuiltinShadow:            false,
+		ShadowBuiltin:            false,
+		ParamNameEqualsType:      false,
+		LargeParamCopy:           false,
+		InlineConstraint:         false,
+		BuiltinPrint:             false,
+		NoGlobalVars:             false,
+		EmptyErrorHandler:        false,
+		SprintfKey:               false,
+		RedundantConversion:      false,
+		DoubleMapLookup:          false,
+		ConstIndexRange:          false,
+		MapRangeOrder:            false,
+		RedundantNamedConversion: false,
+		NestedAppend:             false,
+		DeferCloseNamedErr:       false,
+		MagicNumbers:             false,
+		Init:                     false,
+		RequireExamples:          false,
+		UselessWrap:              false,
+		SliceEquality:            false,
+		NoCopy:                   false,
+		LenCheck:                 false,
+		EmbeddedMutex:            false,
+		MapStructAppend:          false,
+		ShortVarDecl:             false,
+		PredicateNaming:          false,
+		RawString:                false,
+		WrapNonError:             false,
+		UnusedContext:            false,
+		RepeatedCleanup:          false,
+		BreakInSwitch:            false,
+		RedundantContinue:        false,
+		TrailingBreak:            false,
+		ErrorVerb:                false,
+		UseBeforeErrCheck:        false,
+		EnumStringer:             false,
+		MissingNewline:           false,
+		ReturnInterface:          false,
+		RedundantLabel:           false,
+		DuplicateConstValue:      false,
+		UnusedError:              false,
+		HandlerSignature:         false,
+		ThinWrapper:              false,
+		PanicControlFlow:         false,
+
+		MinConfidence:       0.8,
+		Initialisms:         defaultCommonInitialisms,
+		BadReceiverNames:    defaultBadReceiverNames,
+		MaxBlankLines:       1,
+		LargeParamFields:    8,
+		MaxInitStatements:   5,
+		ExamplesMinSymbols:  10,
+		NoCopyTypes:         append([]string(nil), defaultNoCopyTypes...), // decoding a config file reuses the array
+		RawStringMinEscapes: 3,
+		SecretNamePattern:   defaultSecretNamePattern,
+		SecretPlaceholders:  append([]string(nil), defaultSecretPlaceholders...),
 
 		//		IgnoreFiles:      []string{}, // TODO: for future use
 		//		IgnorePackages:   []string{}, // TODO: for future use
@@ -117,6 +383,28 @@ func NewConfig(file string) (*Config, error) {
 			return nil, fmt.Errorf("could not parse configuration from %s: %s", file, err.Error())
 		}
 
+		if _, err := regexp.Compile(c.SecretNamePattern); err != nil {
+			return nil, fmt.Errorf("invalid secret-name-pattern in %s: %s", file, err.Error())
+		}
+		for _, expr := range c.AllowedNameRegexps {
+			if _, err := regexp.Compile(expr); err != nil {
+				return nil, fmt.Errorf("invalid allowed-name-regexps in %s: %s", file, err.Error())
+			}
+		}
+		if c.SortProblems && c.SortByConfidence {
+			return nil, fmt.Errorf("invalid config in %s: sort-problems and sort-by-confidence are mutually exclusive", file)
+		}
+		for _, typ := range c.NoCopyTypes {
+			if i := strings.LastIndex(typ, "."); i <= 0 || i == len(typ)-1 {
+				return nil, fmt.Errorf("invalid no-copy-types in %s: %q is not an import path and type name", file, typ)
+			}
+		}
+		for _, s := range c.AllowedMagicNumbers {
+			if _, ok := parseNumber(s); !ok {
+				return nil, fmt.Errorf("invalid allowed-magic-numbers in %s: %q is not a number", file, s)
+			}
+		}
+
 		sliceToMapBool := func(slice []string) map[string]bool {
 			res := map[string]bool{}
 			for _, v := range slice {
@@ -0,0 +1,317 @@
+package hint
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LintDir lints all the Go source files in the directory tree rooted at dir.
+// Like the go tool, it skips "vendor" and "testdata" directories and
+// directories and files whose names begin with "_" or ".".
+// Files are grouped by directory and package and linted with LintFiles,
+// so package-wide checks see the whole package.
+// Files and directories matching Config.IgnorePatterns or a pattern in
+// a .hintignore file in dir are skipped too, see ignorePatterns.
+// Packages are linted by up to Config.Concurrency goroutines.
+// Problems are sorted by file and position.
+// Files that can't be read or parsed don't stop the others from being linted:
+// the packages they belong to are skipped, and LintDir returns the problems
+// of the other packages along with a DirError listing the errors by file.
+func (l *Linter) LintDir(dir string, config *Config) ([]Problem, error) {
+	if config == nil {
+		config = NewDefaultConfig()
+	}
+	ignore, err := ignorePatterns(dir, config)
+	if err != nil {
+		return nil, err
+	}
+	// dir -> package name -> filename -> source
+	pkgs := make(map[string]map[string]map[string][]byte)
+	// Only the package clauses are parsed here, so one file set will do;
+	// a FileSet is safe for concurrent use.
+	fset := token.NewFileSet()
+	var dirErr DirError
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if path != dir && (skipDir(name) || ignored(ignore, dir, path, true)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(name, ".go") || strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".") {
+			return nil
+		}
+		if ignored(ignore, dir, path, false) {
+			return nil
+		}
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			dirErr.add(err)
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, src, parser.PackageClauseOnly)
+		if err != nil {
+			dirErr.add(err)
+			return nil
+		}
+		d := filepath.Dir(path)
+		if pkgs[d] == nil {
+			pkgs[d] = make(map[string]map[string][]byte)
+		}
+		if pkgs[d][f.Name.Name] == nil {
+			pkgs[d][f.Name.Name] = make(map[string][]byte)
+		}
+		pkgs[d][f.Name.Name][path] = src
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Packages are linted independently, so they can be linted in parallel.
+	// They are listed in a stable order, and their problems merged in it,
+	// so the result doesn't depend on the scheduling.
+	type job struct{ dir, name string }
+	var jobs []job
+	for d, byName := range pkgs {
+		for name := range byName {
+			jobs = append(jobs, job{d, name})
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].dir != jobs[j].dir {
+			return jobs[i].dir < jobs[j].dir
+		}
+		return jobs[i].name < jobs[j].name
+	})
+	workers := config.Concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	results := make([][]Problem, len(jobs))
+	errs := make([]error, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(jobs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				byName := pkgs[jobs[i].dir]
+				files := byName[jobs[i].name]
+				results[i], errs[i] = lintPackage(fset, files, byName[jobs[i].name+"_test"], config)
+				if errs[i] != nil {
+					errs[i] = fileErrors(files, errs[i])
+				}
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var problems []Problem
+	for i := range jobs {
+		dirErr.add(errs[i])
+		problems = append(problems, results[i]...)
+	}
+	sort.Stable(byFilePosition(problems))
+	if len(dirErr) > 0 {
+		return problems, dirErr
+	}
+	return problems, nil
+}
+
+// DirError is the error returned by LintDir when some files couldn't be read or parsed.
+type DirError []error
+
+func (e DirError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// add appends err to e, flattening the errors of a DirError. A nil err is ignored.
+func (e *DirError) add(err error) {
+	switch err := err.(type) {
+	case nil:
+	case DirError:
+		*e = append(*e, err...)
+	default:
+		*e = append(*e, err)
+	}
+}
+
+// fileErrors returns the parse errors of files, a map of filename to source,
+// as a DirError, since lintPackage parses them without their names.
+// If all of them parse, it returns err, the error of lintPackage.
+func fileErrors(files map[string][]byte, err error) error {
+	var names []string
+	for filename := range files {
+		names = append(names, filename)
+	}
+	sort.Strings(names)
+	var dirErr DirError
+	for _, filename := range names {
+		if _, perr := parser.ParseFile(token.NewFileSet(), filename, files[filename], 0); perr != nil {
+			dirErr.add(perr)
+		}
+	}
+	if len(dirErr) == 0 {
+		return err
+	}
+	return dirErr
+}
+
+// lintPackage lints files, a map of filename to source of the files of a
+// package, and returns the problems. external are the files of the external
+// test package in the same directory, if any.
+func lintPackage(fset *token.FileSet, files, external map[string][]byte, config *Config) ([]Problem, error) {
+	pkg, err := newPkg(files, config)
+	if err != nil {
+		return nil, err
+	}
+	if pkg.config.RequireExamples {
+		// Examples are often in the external test package.
+		if pkg.examples, err = externalExamples(fset, external); err != nil {
+			return nil, err
+		}
+	}
+	var problems []Problem
+	pkg.lint(func(p Problem) {
+		problems = append(problems, p)
+	})
+	return problems, nil
+}
+
+// externalExamples returns the names that have an example function,
+// see addExamples, in the test files among files, a map of filename to source.
+func externalExamples(fset *token.FileSet, files map[string][]byte) (map[string]bool, error) {
+	examples := make(map[string]bool)
+	for filename, src := range files {
+		if !strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filename, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		addExamples(examples, f)
+	}
+	return examples, nil
+}
+
+// ignoreFile is the name of the file in the directory linted by LintDir
+// that lists patterns of paths to skip, one per line.
+const ignoreFile = ".hintignore"
+
+// ignorePatterns returns Config.IgnorePatterns and the patterns in the
+// ignore file in dir, if any. Like in a .gitignore file, blank lines and
+// lines starting with # are skipped, a pattern ending with / only matches
+// directories, a pattern containing another / matches paths relative to dir
+// and other patterns match names at any depth. A ** path element matches
+// any number of directories. Negated patterns are not supported.
+func ignorePatterns(dir string, config *Config) ([]string, error) {
+	patterns := append([]string(nil), config.IgnorePatterns...)
+	src, err := ioutil.ReadFile(filepath.Join(dir, ignoreFile))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	for _, pattern := range patterns {
+		for _, elem := range strings.Split(pattern, "/") {
+			if _, err := path.Match(elem, ""); err != nil {
+				return nil, fmt.Errorf("invalid ignore pattern %q: %v", pattern, err)
+			}
+		}
+	}
+	return patterns, nil
+}
+
+// ignored reports whether the file or directory file in the tree rooted
+// at dir matches one of the patterns, see ignorePatterns.
+func ignored(patterns []string, dir, file string, isDir bool) bool {
+	rel, err := filepath.Rel(dir, file)
+	if err != nil {
+		return false
+	}
+	elems := strings.Split(filepath.ToSlash(rel), "/")
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		if !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		if matchElems(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), elems) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchElems reports whether the path elements match the pattern elements,
+// where ** matches any number of path elements.
+func matchElems(pattern, elems []string) bool {
+	if len(pattern) == 0 {
+		return len(elems) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(elems); i++ {
+			if matchElems(pattern[1:], elems[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(elems) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], elems[0])
+	return ok && matchElems(pattern[1:], elems[1:])
+}
+
+// skipDir reports whether the directory with the given name should not be linted.
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || strings.HasPrefix(name, "_") || strings.HasPrefix(name, ".")
+}
+
+type byFilePosition []Problem
+
+func (p byFilePosition) Len() int      { return len(p) }
+func (p byFilePosition) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p byFilePosition) Less(i, j int) bool {
+	if p[i].File != p[j].File {
+		return p[i].File < p[j].File
+	}
+	if p[i].Position.Line != p[j].Position.Line {
+		return p[i].Position.Line < p[j].Position.Line
+	}
+	return p[i].Position.Column < p[j].Position.Column
+}
@@ -0,0 +1,215 @@
+package hint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// writeTree creates the given files, relative to a new temporary directory,
+// and returns the directory.
+func writeTree(t testing.TB, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "hint")
+	if err != nil {
+		t.Fatalf("ioutil.TempDir: %v", err)
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("os.MkdirAll: %v", err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatalf("ioutil.WriteFile: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestLintDir(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"a.go":          "// Package foo does things.\npackage foo\n\nfunc f() { var x int = 0; _ = x }\n",
+		"b.go":          "package foo\n\nfunc g() { var y int = 0; _ = y }\n",
+		"sub/c.go":      "package bar\n",
+		"vendor/v/v.go": "package v\n",
+		"testdata/t.go": "package t\n",
+		"_skip/s.go":    "package s\n",
+		".hidden/h.go":  "package h\n",
+		"_ignored.go":   "package foo\n",
+	})
+	defer os.RemoveAll(dir)
+
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	ps, err := new(Linter).LintDir(dir, config)
+	if err != nil {
+		t.Fatalf("LintDir: %v", err)
+	}
+
+	var got []string
+	for _, p := range ps {
+		rel, err := filepath.Rel(dir, p.File)
+		if err != nil {
+			t.Fatalf("filepath.Rel: %v", err)
+		}
+		got = append(got, filepath.ToSlash(rel)+": "+p.Text)
+	}
+	want := []string{
+		"a.go: should drop = 0 from declaration of var x; it is the zero value",
+		"b.go: should drop = 0 from declaration of var y; it is the zero value",
+		"sub/c.go: should have a package comment, unless it's in another file for this package",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("LintDir returned %d problems, want %d:\n%q", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("problem %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if !sort.IsSorted(byFilePosition(ps)) {
+		t.Errorf("problems are not sorted by file and position")
+	}
+}
+
+func TestLintDirExamples(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"a.go":              "// Package foo does things.\npackage foo\n\n// A does a.\nfunc A() {}\n\n// B does b.\nfunc B() {}\n\n// C is c.\ntype C int\n",
+		"a_test.go":         "package foo\n\nfunc ExampleA() {}\n",
+		"example_test.go":   "package foo_test\n\nfunc ExampleC_String() {}\n",
+		"small/s.go":        "// Package small does little.\npackage small\n\n// S does s.\nfunc S() {}\n",
+		"small/doc_test.go": "package small\n",
+	})
+	defer os.RemoveAll(dir)
+
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	config.Names = false
+	config.RequireExamples = true
+	config.ExamplesMinSymbols = 2
+	ps, err := new(Linter).LintDir(dir, config)
+	if err != nil {
+		t.Fatalf("LintDir: %v", err)
+	}
+	var got []string
+	for _, p := range ps {
+		if p.Category == "docs" {
+			got = append(got, p.Text)
+		}
+	}
+	if want := "exported func B has no example; consider adding ExampleB"; len(got) != 1 || got[0] != want {
+		t.Errorf("LintDir reported %q, want only %q", got, want)
+	}
+}
+
+func TestLintDirIgnore(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		".hintignore":     "# generated code\ngen/\n/api/*.pb.go\n\n",
+		"a.go":            "package foo\n",
+		"gen/g.go":        "package gen\n",
+		"sub/gen/g.go":    "package gen\n",
+		"api/api.go":      "package api\n",
+		"api/api.pb.go":   "package api\n",
+		"sub/api/x.pb.go": "package api\n",
+		"x_string.go":     "package foo\n",
+		"sub/y_string.go": "package sub\n",
+	})
+	defer os.RemoveAll(dir)
+
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	config.IgnorePatterns = []string{"*_string.go"}
+	ps, err := new(Linter).LintDir(dir, config)
+	if err != nil {
+		t.Fatalf("LintDir: %v", err)
+	}
+	var got []string
+	for _, p := range ps {
+		rel, err := filepath.Rel(dir, p.File)
+		if err != nil {
+			t.Fatalf("filepath.Rel: %v", err)
+		}
+		got = append(got, filepath.ToSlash(rel))
+	}
+	want := []string{"a.go", "api/api.go", "sub/api/x.pb.go"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("LintDir reported problems in %q, want %q", got, want)
+	}
+
+	config.IgnorePatterns = []string{"[a-"}
+	if _, err := new(Linter).LintDir(dir, config); err == nil {
+		t.Error("LintDir with an invalid pattern: got nil error")
+	}
+}
+
+func TestLintDirParseErrors(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"a.go":      "// Package foo does things.\npackage foo\n\nfunc f() { var x int = 0; _ = x }\n",
+		"bad/b.go":  "// Package bad does things.\npackage bad\n\nfunc g() {\n",
+		"bad/c.go":  "package bad\n",
+		"clause.go": "packag foo\n",
+		"sub/c.go":  "// Package bar does things.\npackage bar\n\nfunc h() { var y int = 0; _ = y }\n",
+	})
+	defer os.RemoveAll(dir)
+
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	ps, err := new(Linter).LintDir(dir, config)
+	dirErr, ok := err.(DirError)
+	if !ok {
+		t.Fatalf("LintDir returned error %v, want a DirError", err)
+	}
+	var files []string
+	for _, err := range dirErr {
+		files = append(files, filepath.Base(strings.SplitN(err.Error(), ":", 2)[0]))
+	}
+	sort.Strings(files)
+	if want := []string{"b.go", "clause.go"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("LintDir returned errors in %q, want %q:\n%v", files, want, err)
+	}
+	var got []string
+	for _, p := range ps {
+		rel, err := filepath.Rel(dir, p.File)
+		if err != nil {
+			t.Fatalf("filepath.Rel: %v", err)
+		}
+		got = append(got, filepath.ToSlash(rel))
+	}
+	if want := []string{"a.go", "sub/c.go"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LintDir reported problems in %q, want %q", got, want)
+	}
+}
+
+func TestLintDirConcurrency(t *testing.T) {
+	files := make(map[string]string)
+	for i := 0; i < 40; i++ {
+		files[fmt.Sprintf("p%d/f%d.go", i/4, i)] = fmt.Sprintf("package p\n\nvar a_%d int = 0\n", i)
+	}
+	dir := writeTree(t, files)
+	defer os.RemoveAll(dir)
+
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	config.Concurrency = 1
+	want, err := new(Linter).LintDir(dir, config)
+	if err != nil {
+		t.Fatalf("LintDir: %v", err)
+	}
+	if len(want) == 0 {
+		t.Fatal("LintDir returned no problems")
+	}
+	for _, n := range []int{0, 3, 100} {
+		config.Concurrency = n
+		got, err := new(Linter).LintDir(dir, config)
+		if err != nil {
+			t.Fatalf("LintDir: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Concurrency=%d: LintDir returned %d problems different from those with Concurrency=1", n, len(got))
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package hint
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+)
+
+// ApplyFixes applies the suggested fixes of problems to src, the source
+// the problems were reported for, and returns the gofmt-ed result.
+// Problems without a fix are ignored. If fixes overlap, the one with the
+// highest confidence is applied and the others are skipped.
+func ApplyFixes(src []byte, problems []Problem) ([]byte, error) {
+	var fixes []*Problem
+	for i := range problems {
+		if problems[i].Fixable() {
+			fixes = append(fixes, &problems[i])
+		}
+	}
+	if len(fixes) == 0 {
+		return src, nil
+	}
+	sort.Stable(byConfidence(fixes))
+
+	var accepted []*SuggestedFix
+	for _, p := range fixes {
+		fix := p.SuggestedFix
+		if fix.Pos.Offset < 0 || fix.Pos.Offset > fix.End.Offset || fix.End.Offset > len(src) {
+			return nil, fmt.Errorf("fix for %q at %v is out of range", p.Text, p.Position)
+		}
+		conflict := false
+		for _, a := range accepted {
+			if overlaps(fix, a) {
+				conflict = true
+				break
+			}
+		}
+		if !conflict {
+			accepted = append(accepted, fix)
+		}
+	}
+	sort.Sort(byOffset(accepted))
+
+	var buf bytes.Buffer
+	last := 0
+	for _, fix := range accepted {
+		buf.Write(src[last:fix.Pos.Offset])
+		buf.WriteString(fix.NewText)
+		last = fix.End.Offset
+	}
+	buf.Write(src[last:])
+
+	res, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format fixed source: %v", err)
+	}
+	return res, nil
+}
+
+// overlaps reports whether the ranges of a and b overlap.
+// Two insertions at the same offset overlap as well,
+// since the order in which they would be applied is undefined.
+func overlaps(a, b *SuggestedFix) bool {
+	if a.Pos.Offset == b.Pos.Offset {
+		return true
+	}
+	return a.Pos.Offset < b.End.Offset && b.Pos.Offset < a.End.Offset
+}
+
+// byConfidence sorts problems by descending confidence.
+type byConfidence []*Problem
+
+func (p byConfidence) Len() int           { return len(p) }
+func (p byConfidence) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byConfidence) Less(i, j int) bool { return p[i].Confidence > p[j].Confidence }
+
+type byOffset []*SuggestedFix
+
+func (f byOffset) Len() int           { return len(f) }
+func (f byOffset) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f byOffset) Less(i, j int) bool { return f[i].Pos.Offset < f[j].Pos.Offset }
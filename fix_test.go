@@ -0,0 +1,140 @@
+package hint
+
+import (
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestApplyFixes(t *testing.T) {
+	src := []byte(`// Package foo ...
+package foo
+
+func f(m map[int]int) []int {
+	var x int = 0
+	var y int = g()
+	x += 1
+	for k, _ := range m {
+		y -= 1
+		_ = k
+	}
+	s := make([]int, 0)
+	return append(s, x, y)
+}
+`)
+	want := `// Package foo ...
+package foo
+
+func f(m map[int]int) []int {
+	var x int
+	var y = g()
+	x++
+	for k := range m {
+		y--
+		_ = k
+	}
+	var s []int
+	return append(s, x, y)
+}
+`
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	ps, err := new(Linter).Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	var titles []string
+	for _, p := range ps {
+		if p.Fixable() {
+			titles = append(titles, p.FixTitle)
+		}
+	}
+	wantTitles := "Drop zero value, Omit inferred type, Omit second range value, Use ++, Use --, Declare nil slice"
+	if got := strings.Join(titles, ", "); got != wantTitles {
+		t.Errorf("fix titles = %q, want %q", got, wantTitles)
+	}
+	got, err := ApplyFixes(src, ps)
+	if err != nil {
+		t.Fatalf("ApplyFixes: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("ApplyFixes =\n%s\nwant\n%s", got, want)
+	}
+
+	// The fixed source has nothing left to fix.
+	ps, err = new(Linter).Lint("foo.go", config, got)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	if n := CountFixable(ps); n != 0 {
+		t.Errorf("%d fixable problems left after ApplyFixes", n)
+	}
+}
+
+func TestRedundantNamedConversionFix(t *testing.T) {
+	src := []byte(`package foo
+
+type Foo struct{ N int }
+
+type Bar struct{ N int }
+
+func f() {
+	_ = Foo(Foo{N: 1})
+	_ = (*Foo)(&Foo{})
+	_ = (Foo)(Foo{})
+	_ = Bar(Foo{N: 2})
+}
+`)
+	want := `package foo
+
+type Foo struct{ N int }
+
+type Bar struct{ N int }
+
+func f() {
+	_ = Foo{N: 1}
+	_ = &Foo{}
+	_ = Foo{}
+	_ = Bar(Foo{N: 2})
+}
+`
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	config.RedundantNamedConversion = true
+	ps, err := new(Linter).Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	got, err := ApplyFixes(src, ps)
+	if err != nil {
+		t.Fatalf("ApplyFixes: %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("ApplyFixes =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyFixesConflicts(t *testing.T) {
+	src := []byte("package foo\n\nvar x = 1\n")
+	fix := func(pos, end int, text string) *SuggestedFix {
+		return &SuggestedFix{Pos: token.Position{Offset: pos}, End: token.Position{Offset: end}, NewText: text}
+	}
+	ps := []Problem{
+		{Text: "low", Confidence: 0.5, SuggestedFix: fix(17, 22, "y = 2")},
+		{Text: "high", Confidence: 0.9, SuggestedFix: fix(17, 18, "z")},
+		{Text: "unrelated", Confidence: 0.1, SuggestedFix: fix(8, 11, "bar")},
+		{Text: "no fix", Confidence: 1},
+	}
+	got, err := ApplyFixes(src, ps)
+	if err != nil {
+		t.Fatalf("ApplyFixes: %v", err)
+	}
+	if want := "package bar\n\nvar z = 1\n"; string(got) != want {
+		t.Errorf("ApplyFixes = %q, want %q", got, want)
+	}
+
+	ps = []Problem{{Text: "bad", Confidence: 1, SuggestedFix: fix(10, 100, "")}}
+	if _, err := ApplyFixes(src, ps); err == nil {
+		t.Errorf("ApplyFixes with an out of range fix should fail")
+	}
+}
@@ -12,8 +12,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/elgris/hint"
 )
@@ -84,10 +82,11 @@ func lintFile(filename string) {
 }
 
 func lintDir(dirname string) {
-	filepath.Walk(dirname, func(path string, info os.FileInfo, err error) error {
-		if err == nil && !info.IsDir() && strings.HasSuffix(path, ".go") {
-			lintFile(path)
-		}
-		return err
-	})
+	l := new(hint.Linter)
+	// LintDir still returns the problems of the files it could lint on error.
+	ps, err := l.LintDir(dirname, config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	reporter.Collect(ps)
 }
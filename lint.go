@@ -11,12 +11,19 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
 	"go/parser"
 	"go/printer"
 	"go/token"
+	"go/types"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 	"unicode/utf8"
 )
@@ -36,6 +43,21 @@ type Problem struct {
 	Confidence float64        // a value in (0,1] estimating the confidence in this problem's correctness
 	LineText   string         // the source line
 	Category   string         // a short name for the general category of the problem
+
+	SuggestedFix *SuggestedFix // (optional) a change to the source that fixes the problem
+	FixTitle     string        // (optional) a short description of SuggestedFix, e.g. for an editor's quick-fix menu
+}
+
+// SuggestedFix describes a replacement of a range of the source.
+type SuggestedFix struct {
+	Pos     token.Position // start of the range to replace
+	End     token.Position // end of the range to replace (exclusive)
+	NewText string         // the replacement text
+}
+
+// Fixable reports whether the problem comes with a suggested fix.
+func (p *Problem) Fixable() bool {
+	return p.SuggestedFix != nil
 }
 
 func (p *Problem) String() string {
@@ -47,26 +69,270 @@ func (p *Problem) String() string {
 
 // Lint lints src.
 func (l *Linter) Lint(filename string, config *Config, src []byte) ([]Problem, error) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	var problems []Problem
+	err := l.LintFunc(filename, config, src, func(p Problem) {
+		problems = append(problems, p)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return problems, nil
+}
+
+// LintFunc lints src like Lint, but passes the problems to report as they
+// are found instead of returning them all at the end, in the same order.
+// By default problems are reported once all the checks have run on the file;
+// with config.DeclOrder they are reported as each top-level declaration is done,
+// so an editor can show the first problems of a large file early.
+func (l *Linter) LintFunc(filename string, config *Config, src []byte, report func(Problem)) error {
+	pkg, err := newPkg(map[string][]byte{filename: src}, config)
+	if err != nil {
+		return err
+	}
+	pkg.lint(report)
+	return nil
+}
+
+// LintFiles lints a set of files of a single package.
+// The argument is a map of filename to source.
+// Unlike Lint, checks that need to see the whole package, such as
+// the one for the package comment, take all the files into account.
+// If config.BuildConstraints is set, files excluded from the build
+// are skipped.
+func (l *Linter) LintFiles(files map[string][]byte, config *Config) ([]Problem, error) {
+	pkg, err := newPkg(files, config)
 	if err != nil {
 		return nil, err
 	}
-	return (&file{fset: fset, f: f, src: src, filename: filename, config: config}).lint(), nil
+	var problems []Problem
+	pkg.lint(func(p Problem) {
+		problems = append(problems, p)
+	})
+	return problems, nil
+}
+
+// LintCached lints src like Lint, but takes the parsed file from cache,
+// if it has one for src, and adds it otherwise.
+func (l *Linter) LintCached(filename string, config *Config, src []byte, cache *Cache) ([]Problem, error) {
+	pkg, err := newPkgCache(map[string][]byte{filename: src}, config, cache)
+	if err != nil {
+		return nil, err
+	}
+	var problems []Problem
+	pkg.lint(func(p Problem) {
+		problems = append(problems, p)
+	})
+	return problems, nil
+}
+
+// newPkg parses files, a map of filename to source, into a package to lint.
+func newPkg(files map[string][]byte, config *Config) (*pkg, error) {
+	return newPkgCache(files, config, nil)
+}
+
+// newPkgCache is like newPkg, but if cache is not nil and there is a single
+// file, it takes the parsed file from cache or adds it. The files of
+// a package must share a file set, so cache isn't used for more files.
+func newPkgCache(files map[string][]byte, config *Config, cache *Cache) (*pkg, error) {
+	if len(files) != 1 {
+		cache = nil
+	}
+	if config == nil {
+		config = NewDefaultConfig()
+	}
+	pkg := &pkg{
+		fset:   token.NewFileSet(),
+		files:  make(map[string]*file),
+		config: config,
+	}
+	var pkgName string
+	for filename, src := range files {
+		if config.BuildConstraints {
+			ctx := config.BuildContext
+			if ctx == nil {
+				ctx = &build.Default
+			}
+			ok, err := matchBuildContext(ctx, filename, src)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		var f *ast.File
+		if cache != nil {
+			if fset, cached, ok := cache.Get(src); ok {
+				pkg.fset, f = fset, cached
+			}
+		}
+		if f == nil {
+			var err error
+			f, err = parser.ParseFile(pkg.fset, "", src, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+			if cache != nil {
+				cache.Put(src, pkg.fset, f)
+			}
+		}
+		if pkgName == "" {
+			pkgName = f.Name.Name
+		} else if f.Name.Name != pkgName {
+			return nil, fmt.Errorf("%s is in package %s, not %s", filename, f.Name.Name, pkgName)
+		}
+		pkg.files[filename] = &file{
+			pkg:      pkg,
+			fset:     pkg.fset,
+			f:        f,
+			src:      src,
+			filename: filename,
+			config:   config,
+		}
+	}
+	return pkg, nil
+}
+
+// pkg represents a package being linted.
+type pkg struct {
+	fset  *token.FileSet
+	files map[string]*file
+
+	// sortable is the set of types in the package that implement sort.Interface.
+	sortable map[string]bool
+	// errorTypes is the set of types in the package that have an Error() string method.
+	errorTypes map[string]bool
+	// methods maps "Type.Method" to the declarations of the methods in the package.
+	methods map[string]*ast.FuncDecl
+	// interfaceMethods is the set of names of the methods of the interfaces
+	// declared in the package, if Config.UnusedContext is set.
+	interfaceMethods map[string]bool
+	// implementers maps the names of the non-empty interfaces declared at
+	// the top level of the package to the names of the types of the package
+	// that implement them, if Config.ReturnInterface is set.
+	implementers map[string][]string
+	// handlerFuncs is the set of names of the functions passed to a
+	// HandleFunc call in the package, if Config.HandlerSignature is set.
+	handlerFuncs map[string]bool
+	// hasDoc is whether any non-test file of the package has a package comment.
+	hasDoc bool
+	// exported is the number of exported top-level functions and types
+	// in the non-test files of the package.
+	exported int
+	// examples is the set of names that have an example function,
+	// see addExamples. LintDir may add those of the external test package.
+	examples map[string]bool
+	// sentinels maps the names of package-level error variables
+	// created with errors.New or fmt.Errorf to their declarations.
+	sentinels map[string]*ast.ValueSpec
+	// secretNameRE is the compiled Config.SecretNamePattern, or nil if it is invalid.
+	secretNameRE *regexp.Regexp
+	// initialisms is the set of Config.Initialisms, in upper case.
+	initialisms map[string]bool
+	// errorTypeNames is the set of Config.ErrorTypeNames, without pointer stars.
+	errorTypeNames map[string]bool
+	// allowedNameREs are the compiled Config.AllowedNameRegexps that are valid.
+	allowedNameREs []*regexp.Regexp
+	// magicNumbers are the numbers that aren't magic: 0, 1, 2, -1 and
+	// the valid Config.AllowedMagicNumbers.
+	magicNumbers []constant.Value
+
+	// typesPkg and typesInfo hold the result of type checking the package,
+	// if Config.TypeCheck is set. The information may be partial.
+	typesPkg  *types.Package
+	typesInfo *types.Info
+
+	config *Config
+}
+
+// lint lints the files of the package and passes the problems found to report.
+func (p *pkg) lint(report func(Problem)) {
+	if p.config.TypeCheck {
+		p.typeCheck()
+	}
+	p.scanSortable()
+	p.scanErrorTypes()
+	p.scanMethods()
+	p.scanSentinels()
+	p.secretNameRE, _ = regexp.Compile(p.config.SecretNamePattern)
+	p.initialisms = upperSet(p.config.Initialisms)
+	p.errorTypeNames = make(map[string]bool)
+	for _, name := range p.config.ErrorTypeNames {
+		p.errorTypeNames[strings.TrimPrefix(name, "*")] = true
+	}
+	for _, expr := range p.config.AllowedNameRegexps {
+		if re, err := regexp.Compile(expr); err == nil {
+			p.allowedNameREs = append(p.allowedNameREs, re)
+		}
+	}
+	for _, s := range append([]string{"0", "1", "2", "-1"}, p.config.AllowedMagicNumbers...) {
+		if v, ok := parseNumber(s); ok {
+			p.magicNumbers = append(p.magicNumbers, v)
+		}
+	}
+	for _, f := range p.files {
+		if f.f.Doc != nil && !f.isTest() {
+			p.hasDoc = true
+		}
+	}
+	if p.config.RequireExamples {
+		p.scanExamples()
+	}
+	if p.config.UnusedContext {
+		p.scanInterfaceMethods()
+	}
+	if p.config.ReturnInterface {
+		p.scanImplementers()
+	}
+	if p.config.HandlerSignature {
+		p.scanHandlerFuncs()
+	}
+
+	// Lint the files in a stable order.
+	filenames := make([]string, 0, len(p.files))
+	for filename := range p.files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		p.files[filename].lint(report)
+	}
 }
 
 // file represents a file being linted.
 type file struct {
+	pkg      *pkg
 	fset     *token.FileSet
 	f        *ast.File
 	src      []byte
 	filename string
 
-	// sortable is the set of types in the file that implement sort.Interface.
-	sortable map[string]bool
 	// main is whether this file is in a "main" package.
 	main bool
 
+	// decls are the top-level declarations the checks examine,
+	// and header is whether they examine the package clause.
+	// Nodes outside the decls, like comments and imports, are examined
+	// if they are within [lo, hi). By default this covers the whole file;
+	// see lint for how it is narrowed with Config.DeclOrder.
+	decls  []ast.Decl
+	header bool
+	lo, hi token.Pos
+
+	// receiverNames maps receiver types to the receiver name used for them first.
+	receiverNames map[string]string
+	// constraints maps inline constraints, as rendered, to their first use.
+	constraints map[string]token.Pos
+	// recoveredTypes is the set of types, as rendered, that recovered values
+	// are type-asserted to in the file, if Config.PanicControlFlow is set.
+	recoveredTypes map[string]bool
+
+	// dispatcher holds the checks registered with visit, and decl is
+	// the top-level declaration they are being called for.
+	dispatcher *dispatcher
+	decl       ast.Decl
+
 	problems []Problem
 
 	config *Config
@@ -74,15 +340,60 @@ type file struct {
 
 func (f *file) isTest() bool { return strings.HasSuffix(f.filename, "_test.go") }
 
-func (f *file) lint() []Problem {
-	if f.config == nil {
-		f.config = NewDefaultConfig()
+// lint lints the file and passes the problems found to report.
+// By default each check runs over the whole file in turn, so problems are
+// reported grouped by check. With Config.DeclOrder all the checks run on
+// the package clause and then on each top-level declaration in turn, and
+// the problems are reported in source order as soon as a declaration is done,
+// without holding on to the problems of the whole file.
+func (f *file) lint(report func(Problem)) {
+	f.main = f.isMain()
+	f.receiverNames = make(map[string]string)
+	f.constraints = make(map[string]token.Pos)
+	if f.config.PanicControlFlow {
+		f.scanRecoveredTypes()
 	}
 
-	f.scanSortable()
-	f.main = f.isMain()
+	tf := f.fset.File(f.f.Package)
+	start, end := token.Pos(tf.Base()), token.Pos(tf.Base()+tf.Size()+1)
+
+	if !f.config.DeclOrder {
+		f.decls, f.header, f.lo, f.hi = f.f.Decls, true, start, end
+		f.runChecks()
+		f.sortProblems()
+		for _, p := range f.problems {
+			report(p)
+		}
+		return
+	}
+
+	// The first chunk is the package clause and anything up to the first
+	// declaration; every other chunk is a declaration, including its doc
+	// comment, up to the next one.
+	for i := 0; i <= len(f.f.Decls); i++ {
+		f.decls, f.header, f.lo, f.hi = nil, i == 0, start, end
+		if i > 0 {
+			f.decls = f.f.Decls[i-1 : i]
+		}
+		if i < len(f.f.Decls) {
+			f.hi = declStart(f.f.Decls[i])
+		}
+		f.runChecks()
+		sort.Stable(byPosition(f.problems))
+		f.sortProblems()
+		for _, p := range f.problems {
+			report(p)
+		}
+		f.problems = f.problems[:0]
+		start = f.hi
+	}
+}
 
-	if f.config.Package {
+// runChecks runs the configured checks on the nodes in scope (see file.decls).
+// Most checks register a visitor (see file.visit) rather than walking the
+// declarations themselves, so the declarations are walked only once.
+func (f *file) runChecks() {
+	if f.config.Package && f.header {
 		f.lintPackageComment()
 	}
 
@@ -117,6 +428,9 @@ func (f *file) lint() []Problem {
 	} else {
 		f.lintReceiverNames()
 	}
+	if f.config.UnusedReceiver {
+		f.lintUnusedReceiver()
+	}
 
 	f.lintIncDec()
 	if f.config.MakeSlice {
@@ -134,27 +448,319 @@ func (f *file) lint() []Problem {
 		f.lintNamedReturn()
 	}
 
-	return f.problems
+	if f.config.LoopVarCapture && !goVersionAtLeast(f.config.GoVersion, 1, 22) {
+		f.lintLoopVarCapture()
+	}
+
+	if f.config.HardcodedSecret {
+		f.lintHardcodedSecret()
+	}
+
+	if f.config.InsecureRand {
+		f.lintInsecureRand()
+	}
+
+	if f.config.Deprecated {
+		f.lintDeprecatedComment()
+	}
+
+	if f.config.TestSignatures {
+		f.lintTestSignatures()
+	}
+
+	if f.config.WeakHash {
+		f.lintWeakHash()
+	}
+
+	if f.config.InsecureTLS {
+		f.lintInsecureTLS()
+	}
+
+	if f.config.ExecCommand {
+		f.lintExecCommand()
+	}
+
+	if f.config.FilepathJoin {
+		f.lintFilepathJoin()
+	}
+
+	if f.config.UselessSprintf {
+		f.lintUselessSprintf()
+	}
+
+	if f.config.FilePermissions {
+		f.lintFilePermissions()
+	}
+
+	if f.config.GetterNames {
+		f.lintGetterNames()
+	}
+
+	if f.config.ConcreteErrorReturn {
+		f.lintConcreteErrorReturn()
+	}
+
+	if f.config.SubtestT {
+		f.lintSubtestT()
+	}
+
+	if f.config.TableTest {
+		f.lintTableTest()
+	}
+
+	if f.config.CommentSpacing {
+		f.lintCommentSpacing()
+	}
+
+	if f.config.DuplicateCase {
+		f.lintDuplicateCase()
+	}
+
+	if f.config.ExportedSentinels {
+		f.lintExportedSentinels()
+	}
+
+	if f.config.UnexportedSentinel {
+		f.lintUnexportedSentinel()
+	}
+
+	if f.config.BlankLines {
+		f.lintMultipleBlankLines()
+	}
+
+	if f.config.DeadMake {
+		f.lintDeadMake()
+	}
+
+	if f.config.ShadowBuiltin {
+		f.lintShadowBuiltin()
+	} else if f.config.BuiltinShadow {
+		f.lintBuiltinShadow()
+	}
+
+	if f.config.ParamNameEqualsType {
+		f.lintParamNameEqualsType()
+	}
+
+	if f.config.MaxResults > 0 {
+		f.lintResultCount()
+	}
+
+	if f.config.MaxTypeParams > 0 {
+		f.lintTypeParams()
+	}
+
+	if f.config.LargeParamCopy {
+		f.lintLargeParamCopy()
+	}
+
+	if f.config.InlineConstraint {
+		f.lintInlineConstraint()
+	}
+
+	if f.config.BuiltinPrint {
+		f.lintBuiltinPrint()
+	}
+
+	if f.config.NoGlobalVars {
+		f.lintGlobalVars()
+	}
+
+	if f.config.EmptyErrorHandler {
+		f.lintEmptyErrorHandler()
+	}
+
+	if f.config.SprintfKey {
+		f.lintSprintfKey()
+	}
+
+	if f.config.RedundantConversion {
+		f.lintRedundantConversion()
+	}
+
+	if f.config.DoubleMapLookup {
+		f.lintDoubleMapLookup()
+	}
+
+	if f.config.ConstIndexRange {
+		f.lintConstIndexRange()
+	}
+
+	if f.config.MapRangeOrder {
+		f.lintMapRangeOrder()
+	}
+
+	if f.config.RedundantNamedConversion {
+		f.lintRedundantNamedConversion()
+	}
+
+	if f.config.NestedAppend {
+		f.lintNestedAppend()
+	}
+
+	if f.config.DeferCloseNamedErr {
+		f.lintDeferCloseNamedErr()
+	}
+
+	if f.config.MagicNumbers {
+		f.lintMagicNumbers()
+	}
+
+	if f.config.Init {
+		f.lintInit()
+	}
+
+	if f.config.RequireExamples {
+		f.lintMissingExamples()
+	}
+
+	if f.config.UselessWrap {
+		f.lintUselessWrap()
+	}
+
+	if f.config.SliceEquality {
+		f.lintSliceEquality()
+	}
+
+	if f.config.NoCopy {
+		f.lintNoCopy()
+	}
+
+	if f.config.LenCheck {
+		f.lintLenNegative()
+	}
+
+	if f.config.EmbeddedMutex {
+		f.lintEmbeddedMutex()
+	}
+
+	if f.config.MapStructAppend {
+		f.lintMapStructAppend()
+	}
+
+	if f.config.ShortVarDecl {
+		f.lintShortVarDecl()
+	}
+
+	if f.config.PredicateNaming {
+		f.lintPredicateNaming()
+	}
+
+	if f.config.RawString {
+		f.lintRawString()
+	}
+
+	if f.config.WrapNonError {
+		f.lintWrapNonError()
+	}
+
+	if f.config.UnusedContext {
+		f.lintUnusedContext()
+	}
+
+	if f.config.RepeatedCleanup {
+		f.lintRepeatedCleanup()
+	}
+
+	if f.config.BreakInSwitch {
+		f.lintBreakInSwitch()
+	}
+
+	if f.config.RedundantContinue {
+		f.lintRedundantContinue()
+	}
+
+	if f.config.TrailingBreak {
+		f.lintTrailingBreak()
+	}
+
+	if f.config.ErrorVerb {
+		f.lintErrorVerb()
+	}
+
+	if f.config.UseBeforeErrCheck {
+		f.lintUseBeforeErrCheck()
+	}
+
+	if f.config.MaxSwitchCases > 0 {
+		f.lintLargeSwitch()
+	}
+
+	if f.config.EnumStringer {
+		f.lintEnumStringer()
+	}
+
+	if f.config.MissingNewline {
+		f.lintMissingNewline()
+	}
+
+	if f.config.ReturnInterface {
+		f.lintReturnInterface()
+	}
+
+	if f.config.RedundantLabel {
+		f.lintRedundantLabel()
+	}
+
+	if f.config.DuplicateConstValue {
+		f.lintDuplicateConstValue()
+	}
+
+	if f.config.UnusedError {
+		f.lintUnusedError()
+	}
+
+	if f.config.HandlerSignature {
+		f.lintHandlerSignature()
+	}
+
+	if f.config.ThinWrapper {
+		f.lintThinWrapper()
+	}
+
+	if f.config.PanicControlFlow {
+		f.lintPanicControlFlow()
+	}
+
+	f.walkVisitors()
+
+	f.runCustomChecks()
 }
 
 type link string
 type category string
+type fixTitle string
 
-// The variadic arguments may start with link and category types,
+// posNode is an ast.Node for reporting a problem at a position with no node of its own.
+type posNode token.Pos
+
+func (p posNode) Pos() token.Pos { return token.Pos(p) }
+func (p posNode) End() token.Pos { return token.Pos(p) }
+
+// lazyString is a string that is only computed when it is formatted.
+// Checks pass it to errorf, usually through lazyRender, for anything that is
+// expensive to compute, so problems dropped for their confidence cost nothing.
+type lazyString func() string
+
+func (s lazyString) String() string { return s() }
+
+// lazyRender returns the source of x as a lazyString.
+func (f *file) lazyRender(x interface{}) lazyString {
+	return func() string { return f.render(x) }
+}
+
+// The variadic arguments may start with link, category and *SuggestedFix types,
+// or a func returning the *SuggestedFix to compute it only if the problem is reported,
 // and must end with a format string and any arguments.
+// Problems below Config.MinConfidence, or the Config.CategoryMinConfidence
+// of their category, are dropped.
 func (f *file) errorf(n ast.Node, confidence float64, args ...interface{}) {
-	if confidence < f.config.MinConfidence {
+	if confidence < f.config.MinConfidence && len(f.config.CategoryMinConfidence) == 0 {
 		return
 	}
 
-	p := f.fset.Position(n.Pos())
-	problem := Problem{
-		File:       f.filename,
-		Position:   p,
-		Confidence: confidence,
-		LineText:   srcLine(f.src, p),
-	}
-
+	var problem Problem
+	var fix func() *SuggestedFix
 argLoop:
 	for len(args) > 1 { // always leave at least the format string in args
 		switch v := args[0].(type) {
@@ -162,19 +768,47 @@ argLoop:
 			problem.Link = string(v)
 		case category:
 			problem.Category = string(v)
+		case *SuggestedFix:
+			problem.SuggestedFix = v
+		case func() *SuggestedFix:
+			fix = v
+		case fixTitle:
+			problem.FixTitle = string(v)
 		default:
 			break argLoop
 		}
 		args = args[1:]
 	}
 
+	min, ok := f.config.CategoryMinConfidence[problem.Category]
+	if !ok {
+		min = f.config.MinConfidence
+	}
+	if confidence < min {
+		return
+	}
+
+	p := f.fset.Position(n.Pos())
+	problem.File = f.filename
+	if f.config.NormalizePaths {
+		problem.File = strings.Replace(problem.File, `\`, "/", -1)
+	}
+	problem.Position = p
+	problem.Confidence = confidence
+	problem.LineText = srcLine(f.src, p)
+	if fix != nil {
+		problem.SuggestedFix = fix()
+	}
+	if problem.SuggestedFix == nil {
+		problem.FixTitle = ""
+	}
 	problem.Text = fmt.Sprintf(args[0].(string), args[1:]...)
 
 	f.problems = append(f.problems, problem)
 }
 
-func (f *file) scanSortable() {
-	f.sortable = make(map[string]bool)
+func (p *pkg) scanSortable() {
+	p.sortable = make(map[string]bool)
 
 	// bitfield for which methods exist on each type.
 	const (
@@ -184,37 +818,341 @@ func (f *file) scanSortable() {
 	)
 	nmap := map[string]int{"Len": Len, "Less": Less, "Swap": Swap}
 	has := make(map[string]int)
-	f.walk(func(n ast.Node) bool {
-		fn, ok := n.(*ast.FuncDecl)
-		if !ok || fn.Recv == nil {
-			return true
-		}
-		// TODO(dsymonds): We could check the signature to be more precise.
-		recv := receiverType(fn)
-		if i, ok := nmap[fn.Name.Name]; ok {
-			has[recv] |= i
+	for _, f := range p.files {
+		for _, decl := range f.f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil {
+				continue
+			}
+			// TODO(dsymonds): We could check the signature to be more precise.
+			recv := receiverType(fn)
+			if i, ok := nmap[fn.Name.Name]; ok {
+				has[recv] |= i
+			}
 		}
-		return false
-	})
+	}
 	for typ, ms := range has {
 		if ms == Len|Less|Swap {
-			f.sortable[typ] = true
+			p.sortable[typ] = true
 		}
 	}
 }
 
-func (f *file) isMain() bool {
-	if f.f.Name.Name == "main" {
-		return true
-	}
-	return false
+// scanErrorTypes records the types in the package that have an Error() string method.
+func (p *pkg) scanErrorTypes() {
+	p.errorTypes = make(map[string]bool)
+	for _, f := range p.files {
+		for _, decl := range f.f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Name.Name != "Error" {
+				continue
+			}
+			ft := fn.Type
+			if ft.Params.NumFields() == 0 && ft.Results.NumFields() == 1 && isIdent(ft.Results.List[0].Type, "string") {
+				p.errorTypes[receiverType(fn)] = true
+			}
+		}
+	}
+}
+
+// scanExamples counts the exported functions and types of the package
+// and records the names that have an example function.
+func (p *pkg) scanExamples() {
+	if p.examples == nil {
+		p.examples = make(map[string]bool)
+	}
+	for _, f := range p.files {
+		if f.isTest() {
+			addExamples(p.examples, f.f)
+			continue
+		}
+		for _, decl := range f.f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil && d.Name.IsExported() {
+					p.exported++
+				}
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					if spec.(*ast.TypeSpec).Name.IsExported() {
+						p.exported++
+					}
+				}
+			}
+		}
+	}
+}
+
+// scanMethods records the methods declared in the package by their receiver type.
+func (p *pkg) scanMethods() {
+	p.methods = make(map[string]*ast.FuncDecl)
+	for _, f := range p.files {
+		for _, decl := range f.f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil {
+				continue
+			}
+			if recv := receiverType(fn); recv != "" {
+				p.methods[recv+"."+fn.Name.Name] = fn
+			}
+		}
+	}
+}
+
+// scanInterfaceMethods records the names of the methods of the interfaces
+// declared in the package, including those in function bodies.
+func (p *pkg) scanInterfaceMethods() {
+	p.interfaceMethods = make(map[string]bool)
+	for _, f := range p.files {
+		ast.Inspect(f.f, func(n ast.Node) bool {
+			it, ok := n.(*ast.InterfaceType)
+			if !ok {
+				return true
+			}
+			for _, field := range it.Methods.List {
+				for _, name := range field.Names {
+					p.interfaceMethods[name.Name] = true
+				}
+			}
+			return true
+		})
+	}
+}
+
+// scanHandlerFuncs records the names of the functions passed to a
+// HandleFunc call, like http.HandleFunc("/", index) or
+// mux.HandleFunc("/", index), in the package.
+func (p *pkg) scanHandlerFuncs() {
+	p.handlerFuncs = make(map[string]bool)
+	for _, f := range p.files {
+		ast.Inspect(f.f, func(n ast.Node) bool {
+			ce, ok := n.(*ast.CallExpr)
+			if !ok || len(ce.Args) != 2 {
+				return true
+			}
+			if sel, ok := ce.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "HandleFunc" {
+				return true
+			}
+			if id, ok := ce.Args[1].(*ast.Ident); ok {
+				p.handlerFuncs[id.Name] = true
+			}
+			return true
+		})
+	}
+}
+
+// scanImplementers records the types of the package implementing each
+// non-empty interface declared at its top level, in p.implementers.
+// Pointer types are named with a star if only they implement the interface.
+// Without type information, a type implements an interface if it has
+// methods with the names of the interface's, and interfaces embedding
+// others are skipped.
+func (p *pkg) scanImplementers() {
+	p.implementers = make(map[string][]string)
+	if p.typesPkg != nil {
+		scope := p.typesPkg.Scope()
+		for _, iname := range scope.Names() {
+			itn, ok := scope.Lookup(iname).(*types.TypeName)
+			if !ok {
+				continue
+			}
+			iface, ok := itn.Type().Underlying().(*types.Interface)
+			if !ok || iface.NumMethods() == 0 {
+				continue
+			}
+			p.implementers[iname] = nil
+			for _, name := range scope.Names() {
+				tn, ok := scope.Lookup(name).(*types.TypeName)
+				if !ok || types.IsInterface(tn.Type()) {
+					continue
+				}
+				switch {
+				case types.Implements(tn.Type(), iface):
+					p.implementers[iname] = append(p.implementers[iname], name)
+				case types.Implements(types.NewPointer(tn.Type()), iface):
+					p.implementers[iname] = append(p.implementers[iname], "*"+name)
+				}
+			}
+		}
+		return
+	}
+
+	interfaces := make(map[string][]string) // interface name -> method names
+	var concrete []string
+	for _, f := range p.files {
+		for _, decl := range f.f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				it, ok := ts.Type.(*ast.InterfaceType)
+				if !ok {
+					concrete = append(concrete, ts.Name.Name)
+					continue
+				}
+				var methods []string
+				embeds := false
+				for _, field := range it.Methods.List {
+					for _, name := range field.Names {
+						methods = append(methods, name.Name)
+					}
+					embeds = embeds || len(field.Names) == 0
+				}
+				if !embeds && len(methods) > 0 {
+					interfaces[ts.Name.Name] = methods
+				}
+			}
+		}
+	}
+	sort.Strings(concrete)
+	for iname, methods := range interfaces {
+		p.implementers[iname] = nil
+	types:
+		for _, name := range concrete {
+			for _, m := range methods {
+				if p.methods[name+"."+m] == nil {
+					continue types
+				}
+			}
+			p.implementers[iname] = append(p.implementers[iname], name)
+		}
+	}
+}
+
+// scanSentinels records the package-level error variables created with errors.New or fmt.Errorf.
+func (p *pkg) scanSentinels() {
+	p.sentinels = make(map[string]*ast.ValueSpec)
+	for _, f := range p.files {
+		for _, decl := range f.f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, id := range vs.Names {
+					if i < len(vs.Values) && isErrorConstructor(vs.Values[i]) {
+						p.sentinels[id.Name] = vs
+					}
+				}
+			}
+		}
+	}
+}
+
+// isErrorConstructor reports whether expr is a call to errors.New or fmt.Errorf.
+func isErrorConstructor(expr ast.Expr) bool {
+	ce, ok := expr.(*ast.CallExpr)
+	return ok && (isPkgDot(ce.Fun, "errors", "New") || isPkgDot(ce.Fun, "fmt", "Errorf"))
+}
+
+// typeCheck type checks the package and records the result in p.
+// Type errors, for instance from unresolvable imports, are ignored;
+// the information gathered is still useful even if it is partial.
+func (p *pkg) typeCheck() {
+	config := &types.Config{
+		Error:    func(error) {}, // don't stop at the first error
+		Importer: importer.Default(),
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	var name string
+	var files []*ast.File
+	for _, f := range p.files {
+		name = f.f.Name.Name
+		files = append(files, f.f)
+	}
+	p.typesPkg, _ = config.Check(name, p.fset, files, info)
+	p.typesInfo = info
+}
+
+// typeOf returns the type of expr, or nil if it is not known.
+func (f *file) typeOf(expr ast.Expr) types.Type {
+	if f.pkg.typesInfo == nil {
+		return nil
+	}
+	return f.pkg.typesInfo.TypeOf(expr)
+}
+
+// commentsAfter returns the comment groups of the file that start at or after pos.
+// The comments of a file are sorted by position, so they are binary-searched.
+func (f *file) commentsAfter(pos token.Pos) []*ast.CommentGroup {
+	cgs := f.f.Comments
+	i := sort.Search(len(cgs), func(i int) bool { return cgs[i].Pos() >= pos })
+	return cgs[i:]
+}
+
+// inScope reports whether pos is within the part of the file being checked.
+func (f *file) inScope(pos token.Pos) bool {
+	return f.lo <= pos && pos < f.hi
+}
+
+// declStart returns the position of the start of decl, including its doc comment.
+func declStart(decl ast.Decl) token.Pos {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			return d.Doc.Pos()
+		}
+	}
+	return decl.Pos()
+}
+
+// byPosition sorts the problems of a file by position.
+type byPosition []Problem
+
+func (p byPosition) Len() int           { return len(p) }
+func (p byPosition) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p byPosition) Less(i, j int) bool { return p[i].Position.Offset < p[j].Position.Offset }
+
+// byConfidencePosition sorts the problems of a file by confidence,
+// highest first, and then by position.
+type byConfidencePosition []Problem
+
+func (p byConfidencePosition) Len() int      { return len(p) }
+func (p byConfidencePosition) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p byConfidencePosition) Less(i, j int) bool {
+	if p[i].Confidence != p[j].Confidence {
+		return p[i].Confidence > p[j].Confidence
+	}
+	return p[i].Position.Offset < p[j].Position.Offset
+}
+
+// sortProblems sorts f.problems as requested by Config.SortProblems
+// or Config.SortByConfidence.
+func (f *file) sortProblems() {
+	switch {
+	case f.config.SortByConfidence:
+		sort.Stable(byConfidencePosition(f.problems))
+	case f.config.SortProblems:
+		sort.Stable(byPosition(f.problems))
+	}
+}
+
+func (f *file) isMain() bool {
+	if f.f.Name.Name == "main" {
+		return true
+	}
+	return false
 }
 
 // lintPackageComment checks package comments. It complains if
 // there is no package comment, or if it is not of the right form.
-// This has a notable false positive in that a package comment
-// could rightfully appear in a different file of the same package,
-// but that's not easy to fix since this linter is file-oriented.
+// When linting a single file with Lint this has a notable false positive
+// in that a package comment could rightfully appear in a different file
+// of the same package; LintFiles takes the other files into account.
 func (f *file) lintPackageComment() {
 	if f.isTest() {
 		return
@@ -222,6 +1160,9 @@ func (f *file) lintPackageComment() {
 
 	const ref = styleGuideBase + "#Package_Comments"
 	if f.f.Doc == nil {
+		if f.pkg.hasDoc {
+			return
+		}
 		f.errorf(f.f, 0.2, link(ref), category("comments"), "should have a package comment, unless it's in another file for this package")
 		return
 	}
@@ -248,6 +1189,9 @@ func (f *file) lintBlankImports() {
 	// The first element of each contiguous group of blank imports should have
 	// an explanatory comment of some kind.
 	for i, imp := range f.f.Imports {
+		if !f.inScope(imp.Pos()) {
+			continue
+		}
 		pos := f.fset.Position(imp.Pos())
 
 		if !isBlank(imp.Name) {
@@ -273,6 +1217,9 @@ func (f *file) lintBlankImports() {
 func (f *file) lintImports() {
 
 	for _, is := range f.f.Imports {
+		if !f.inScope(is.Pos()) {
+			continue
+		}
 		if is.Name != nil && is.Name.Name == "." && !f.isTest() {
 			f.errorf(is, 1, link(styleGuideBase+"#Import_Dot"), category("imports"), "should not use dot imports")
 		}
@@ -299,7 +1246,7 @@ func (f *file) lintExported(allowPackagePrefix bool) {
 	// Set of GenDecls that have already had missing comments flagged.
 	genDeclMissingComments := make(map[*ast.GenDecl]bool)
 
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		switch v := node.(type) {
 		case *ast.GenDecl:
 			if v.Tok == token.IMPORT {
@@ -338,7 +1285,7 @@ func (f *file) lintExported(allowPackagePrefix bool) {
 			return false
 		}
 		return true
-	})
+	}, (*ast.GenDecl)(nil), (*ast.FuncDecl)(nil), (*ast.TypeSpec)(nil), (*ast.ValueSpec)(nil))
 }
 
 var allCapsRE = regexp.MustCompile(`^[A-Z0-9_]+$`)
@@ -348,7 +1295,7 @@ var allCapsRE = regexp.MustCompile(`^[A-Z0-9_]+$`)
 func (f *file) lintNames() {
 	// Package names need slightly different handling than other names.
 	// TODO: make it optional or warning
-	if f.config.PackageUnderscore && strings.Contains(f.f.Name.Name, "_") && !strings.HasSuffix(f.f.Name.Name, "_test") {
+	if f.header && f.config.PackageUnderscore && strings.Contains(f.f.Name.Name, "_") && !strings.HasSuffix(f.f.Name.Name, "_test") {
 		f.errorf(f.f, 1, link("http://golang.org/doc/effective_go.html#package-names"), category("naming"), "don't use an underscore in package name")
 	}
 
@@ -356,9 +1303,11 @@ func (f *file) lintNames() {
 		if id.Name == "_" {
 			return
 		}
+		// Allowed names are exempt from the underscore and initialism rules.
+		allowed := f.pkg.allowedName(id.Name)
 
 		// Handle two common styles from other languages that don't belong in Go.
-		if len(id.Name) >= 5 && allCapsRE.MatchString(id.Name) && strings.Contains(id.Name, "_") {
+		if !allowed && len(id.Name) >= 5 && allCapsRE.MatchString(id.Name) && strings.Contains(id.Name, "_") {
 			// TODO: make it optional
 			f.errorf(id, 0.6, link(styleGuideBase+"#Mixed_Caps"), category("naming"), "don't use ALL_CAPS in Go names; use CamelCase")
 			return
@@ -368,6 +1317,9 @@ func (f *file) lintNames() {
 			// TODO: why? make it optional?
 			f.errorf(id, 0.6, link(styleGuideBase+"#Mixed_Caps"), category("naming"), "don't use leading k in Go names; %s %s should be %s", thing, id.Name, should)
 		}
+		if allowed {
+			return
+		}
 
 		should := f.fixName(id.Name)
 		if id.Name == should {
@@ -389,7 +1341,7 @@ func (f *file) lintNames() {
 			}
 		}
 	}
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		switch v := node.(type) {
 		case *ast.AssignStmt:
 			if v.Tok == token.ASSIGN {
@@ -411,6 +1363,7 @@ func (f *file) lintNames() {
 			if v.Recv != nil {
 				thing = "method"
 			}
+			checkList(v.Type.TypeParams, thing+" type parameter")
 			checkList(v.Type.Params, thing+" parameter")
 			checkList(v.Type.Results, thing+" result")
 		case *ast.GenDecl:
@@ -430,6 +1383,7 @@ func (f *file) lintNames() {
 				switch s := spec.(type) {
 				case *ast.TypeSpec:
 					check(s.Name, thing)
+					checkList(s.TypeParams, "type parameter")
 				case *ast.ValueSpec:
 					for _, id := range s.Names {
 						check(id, thing)
@@ -465,7 +1419,21 @@ func (f *file) lintNames() {
 			}
 		}
 		return true
-	})
+	}, (*ast.AssignStmt)(nil), (*ast.FuncDecl)(nil), (*ast.GenDecl)(nil), (*ast.InterfaceType)(nil), (*ast.RangeStmt)(nil), (*ast.StructType)(nil))
+}
+
+// allowedName reports whether name is exempt from the naming rules by
+// Config.AllowedUnderscoreNames or Config.AllowedNameRegexps.
+func (p *pkg) allowedName(name string) bool {
+	if p.config.AllowedUnderscoreNames[name] {
+		return true
+	}
+	for _, re := range p.allowedNameREs {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
 }
 
 // fixName returns a different name if it should be different.
@@ -485,9 +1453,23 @@ func (f *file) fixName(name string) (should string) {
 		return name
 	}
 
+	// Most names don't need to change, so work on a buffer on the stack
+	// and only make a new string if something did.
+	var buf [64]rune
+	runes := buf[:0]
+	for _, r := range name {
+		runes = append(runes, r)
+	}
+	changed := false
+	set := func(i int, r rune) {
+		if runes[i] != r {
+			runes[i] = r
+			changed = true
+		}
+	}
+
 	// Split camelCase at any lower->upper transition, and split on underscores.
 	// Check each word for common initialisms.
-	runes := []rune(name)
 	w, i := 0, 0 // index of start of word, scan
 	for i+1 <= len(runes) {
 		eow := false // whether we hit the end of a word
@@ -502,6 +1484,7 @@ func (f *file) fixName(name string) (should string) {
 			}
 			copy(runes[i+1:], runes[i+n+1:])
 			runes = runes[:len(runes)-n]
+			changed = true
 		} else if unicode.IsLower(runes[i]) && !unicode.IsLower(runes[i+1]) {
 			// lower->non-lower
 			eow = true
@@ -512,25 +1495,62 @@ func (f *file) fixName(name string) (should string) {
 		}
 
 		// [w,i) is a word.
-		word := string(runes[w:i])
-		// TODO: configure initialisms here
-		if u := strings.ToUpper(word); f.config.Initialisms[u] {
+		if f.pkg.isInitialism(runes[w:i]) {
 			// Keep consistent case, which is lowercase only at the start.
+			toCase := unicode.ToUpper
 			if w == 0 && unicode.IsLower(runes[w]) {
-				u = strings.ToLower(u)
+				toCase = unicode.ToLower
+			}
+			for j := w; j < i; j++ {
+				set(j, toCase(runes[j]))
 			}
-			// All the common initialisms are ASCII,
-			// so we can replace the bytes exactly.
-			copy(runes[w:], []rune(u))
-		} else if w > 0 && strings.ToLower(word) == word {
+		} else if w > 0 && isLowerCase(runes[w:i]) {
 			// already all lowercase, and not the first word, so uppercase the first character.
-			runes[w] = unicode.ToUpper(runes[w])
+			set(w, unicode.ToUpper(runes[w]))
 		}
 		w = i
 	}
+	if !changed {
+		return name
+	}
 	return string(runes)
 }
 
+// isInitialism reports whether word is one of the configured initialisms, in any case.
+func (p *pkg) isInitialism(word []rune) bool {
+	// Upper-case the word into a buffer on the stack; looking up
+	// a converted byte slice in a map doesn't allocate.
+	var buf [32]byte
+	b := buf[:0]
+	for _, r := range word {
+		var rb [utf8.UTFMax]byte
+		n := utf8.EncodeRune(rb[:], unicode.ToUpper(r))
+		b = append(b, rb[:n]...)
+	}
+	return p.initialisms[string(b)]
+}
+
+// isLowerCase reports whether word has no upper case letters.
+func isLowerCase(word []rune) bool {
+	for _, r := range word {
+		if unicode.ToLower(r) != r {
+			return false
+		}
+	}
+	return true
+}
+
+// upperSet returns the keys of m that are set to true, in upper case.
+func upperSet(m map[string]bool) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for k, v := range m {
+		if v {
+			set[strings.ToUpper(k)] = true
+		}
+	}
+	return set
+}
+
 // lintTypeDoc examines the doc comment on a type.
 // It complains if they are missing from an exported type,
 // or if they are not of the standard form.
@@ -588,7 +1608,7 @@ func (f *file) lintFuncDoc(fn *ast.FuncDecl) {
 		}
 		switch name {
 		case "Len", "Less", "Swap":
-			if f.sortable[recv] {
+			if f.pkg.sortable[recv] {
 				return
 			}
 		}
@@ -608,6 +1628,8 @@ func (f *file) lintFuncDoc(fn *ast.FuncDecl) {
 // lintValueSpecDoc examines package-global variables and constants.
 // It complains if they are not individually declared,
 // or if they are not suitably documented in the right form (unless they are in a block that is commented).
+// With Config.RequireEachConstDoc each exported constant of a block needs
+// its own doc or line comment.
 func (f *file) lintValueSpecDoc(vs *ast.ValueSpec, gd *ast.GenDecl, genDeclMissingComments map[*ast.GenDecl]bool) {
 	kind := "var"
 	if gd.Tok == token.CONST {
@@ -631,6 +1653,12 @@ func (f *file) lintValueSpecDoc(vs *ast.ValueSpec, gd *ast.GenDecl, genDeclMissi
 	}
 
 	if vs.Doc == nil {
+		if f.config.RequireEachConstDoc && kind == "const" && gd.Lparen.IsValid() {
+			if vs.Comment == nil {
+				f.errorf(vs, 1, link(docCommentsLink), category("comments"), "exported const %s should have its own comment, not only one on its block, or be unexported", name)
+			}
+			return
+		}
 		if gd.Doc == nil && !genDeclMissingComments[gd] {
 			block := ""
 			if kind == "const" && gd.Lparen.IsValid() {
@@ -694,7 +1722,7 @@ var zeroLiteral = map[string]bool{
 func (f *file) lintVarDecls() {
 	var lastGen *ast.GenDecl // last GenDecl entered.
 
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		switch v := node.(type) {
 		case *ast.GenDecl:
 			if v.Tok != token.CONST && v.Tok != token.VAR {
@@ -723,7 +1751,7 @@ func (f *file) lintVarDecls() {
 				zero = true
 			}
 			if zero {
-				f.errorf(rhs, 0.9, category("zero-value"), "should drop = %s from declaration of var %s; it is the zero value", f.render(rhs), v.Names[0])
+				f.errorf(rhs, 0.9, category("zero-value"), fixTitle("Drop zero value"), f.fix(v.Type.End(), rhs.End(), ""), "should drop = %s from declaration of var %s; it is the zero value", f.lazyRender(rhs), v.Names[0])
 				return false
 			}
 			// If the LHS type is an interface, don't warn, since it is probably a
@@ -738,11 +1766,64 @@ func (f *file) lintVarDecls() {
 			if defType, ok := isUntypedConst(rhs); ok && !isIdent(v.Type, defType) {
 				return false
 			}
-			f.errorf(v.Type, 0.8, category("type-inference"), "should omit type %s from declaration of var %s; it will be inferred from the right-hand side", f.render(v.Type), v.Names[0])
+			f.errorf(v.Type, 0.8, category("type-inference"), fixTitle("Omit inferred type"), f.fix(v.Names[0].End(), v.Type.End(), ""), "should omit type %s from declaration of var %s; it will be inferred from the right-hand side", f.lazyRender(v.Type), v.Names[0])
 			return false
 		}
 		return true
-	})
+	}, (*ast.GenDecl)(nil), (*ast.ValueSpec)(nil))
+}
+
+// lintShortVarDecl examines variable declarations in functions.
+// It complains about those of a single spec with values, like var x = 5,
+// that could be the short variable declaration x := 5. Declarations with
+// a type are only considered when the values are untyped constants, since
+// the type would otherwise be lost; see lintVarDecls for redundant types.
+func (f *file) lintShortVarDecl() {
+	f.visit(func(n ast.Node) bool {
+		ds := n.(*ast.DeclStmt)
+		gd, ok := ds.Decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR || gd.Lparen.IsValid() || len(gd.Specs) != 1 {
+			return true
+		}
+		vs := gd.Specs[0].(*ast.ValueSpec)
+		if len(vs.Values) != len(vs.Names) {
+			return true
+		}
+		allBlank := true
+		for _, name := range vs.Names {
+			allBlank = allBlank && isBlank(name)
+		}
+		if allBlank {
+			return true
+		}
+		if vs.Type != nil {
+			if _, ok := vs.Type.(*ast.InterfaceType); ok {
+				return true
+			}
+			for _, v := range vs.Values {
+				if _, ok := isUntypedConst(v); !ok {
+					return true
+				}
+			}
+		}
+		short := func() string {
+			var names, values []string
+			for i, name := range vs.Names {
+				names = append(names, name.Name)
+				value := f.render(vs.Values[i])
+				if defType, _ := isUntypedConst(vs.Values[i]); vs.Type != nil && !isIdent(vs.Type, defType) {
+					value = f.render(vs.Type) + "(" + value + ")"
+				}
+				values = append(values, value)
+			}
+			return strings.Join(names, ", ") + " := " + strings.Join(values, ", ")
+		}
+		fix := func() *SuggestedFix {
+			return f.fix(ds.Pos(), ds.End(), short())
+		}
+		f.errorf(ds, 0.6, category("declarations"), fixTitle("Use short variable declaration"), fix, "should use the short variable declaration %s instead of var", lazyString(short))
+		return true
+	}, (*ast.DeclStmt)(nil))
 }
 
 // lintElses examines else blocks. It complains about any else block whose if block ends in a return.
@@ -752,7 +1833,7 @@ func (f *file) lintElses() {
 	// Record such a node so we ignore it when we visit it.
 	ignore := make(map[*ast.IfStmt]bool)
 
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		ifStmt, ok := node.(*ast.IfStmt)
 		if !ok || ifStmt.Else == nil {
 			return true
@@ -786,12 +1867,12 @@ func (f *file) lintElses() {
 			f.errorf(ifStmt.Else, 1, link(styleGuideBase+"#Indent_Error_Flow"), category("indent"), "if block ends with a return statement, so drop this else and outdent its block"+extra)
 		}
 		return true
-	})
+	}, (*ast.IfStmt)(nil))
 }
 
 // lintRanges examines range clauses. It complains about redundant constructions.
 func (f *file) lintRanges() {
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		rs, ok := node.(*ast.RangeStmt)
 		if !ok {
 			return true
@@ -805,14 +1886,14 @@ func (f *file) lintRanges() {
 			return true
 		}
 
-		f.errorf(rs.Value, 1, category("range-loop"), "should omit 2nd value from range; this loop is equivalent to `for %s %s range ...`", f.render(rs.Key), rs.Tok)
+		f.errorf(rs.Value, 1, category("range-loop"), fixTitle("Omit second range value"), f.fix(rs.Key.End(), rs.Value.End(), ""), "should omit 2nd value from range; this loop is equivalent to `for %s %s range ...`", f.lazyRender(rs.Key), rs.Tok)
 		return true
-	})
+	}, (*ast.RangeStmt)(nil))
 }
 
 // lintErrorf examines errors.New calls. It complains if its only argument is an fmt.Sprintf invocation.
 func (f *file) lintErrorf() {
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		ce, ok := node.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -827,39 +1908,58 @@ func (f *file) lintErrorf() {
 		}
 		f.errorf(node, 1, category("errors"), "should replace errors.New(fmt.Sprintf(...)) with fmt.Errorf(...)")
 		return true
-	})
+	}, (*ast.CallExpr)(nil))
 }
 
 // lintErrors examines global error vars. It complains if they aren't named in the standard way.
 func (f *file) lintErrors() {
-	for _, decl := range f.f.Decls {
-		gd, ok := decl.(*ast.GenDecl)
+	f.visit(func(n ast.Node) bool {
+		gd, ok := n.(*ast.GenDecl)
 		if !ok || gd.Tok != token.VAR {
-			continue
+			// Only package-level vars are of interest.
+			return false
 		}
 		for _, spec := range gd.Specs {
 			spec := spec.(*ast.ValueSpec)
-			if len(spec.Names) != 1 || len(spec.Values) != 1 {
-				continue
-			}
-			ce, ok := spec.Values[0].(*ast.CallExpr)
-			if !ok {
+			if len(spec.Names) != len(spec.Values) {
 				continue
 			}
-			if !isPkgDot(ce.Fun, "errors", "New") && !isPkgDot(ce.Fun, "fmt", "Errorf") {
-				continue
-			}
-
-			id := spec.Names[0]
-			prefix := "err"
-			if id.IsExported() {
-				prefix = "Err"
-			}
-			if !strings.HasPrefix(id.Name, prefix) {
-				f.errorf(id, 0.9, category("naming"), "error var %s should have name of the form %sFoo", id.Name, prefix)
+			for i, id := range spec.Names {
+				if len(spec.Names) != 1 && !f.config.ErrorVarNamingStrict {
+					break
+				}
+				if !isErrorConstructor(spec.Values[i]) && !f.isErrorVarSpec(spec, i) {
+					continue
+				}
+				prefix := "err"
+				if id.IsExported() {
+					prefix = "Err"
+				}
+				// Blank vars, like var _ error = (*T)(nil), are only skipped in strict mode.
+				if (!isBlank(id) || !f.config.ErrorVarNamingStrict) && !strings.HasPrefix(id.Name, prefix) {
+					f.errorf(id, 0.9, category("naming"), "error var %s should have name of the form %sFoo", id.Name, prefix)
+				}
 			}
 		}
+		return false
+	}, (*ast.GenDecl)(nil), (*ast.FuncDecl)(nil))
+}
+
+// isErrorVarSpec reports whether Config.ErrorVarNamingStrict is set and
+// the i'th variable of spec is declared with an error type, see isErrorType,
+// or initialized with a composite literal of one, like &MyError{}.
+func (f *file) isErrorVarSpec(spec *ast.ValueSpec, i int) bool {
+	if !f.config.ErrorVarNamingStrict {
+		return false
+	}
+	if spec.Type != nil {
+		return f.isErrorType(spec.Type)
+	}
+	typ := literalType(spec.Values[i])
+	if _, ok := spec.Values[i].(*ast.CallExpr); ok || typ == nil {
+		return false
 	}
+	return f.isErrorType(typ)
 }
 
 func lintCapAndPunct(s string) (isCap, isPunct bool) {
@@ -878,7 +1978,7 @@ func lintCapAndPunct(s string) (isCap, isPunct bool) {
 
 // lintErrorStrings examines error strings. It complains if they are capitalized or end in punctuation.
 func (f *file) lintErrorStrings() {
-	f.walk(func(node ast.Node) bool {
+	f.visit(func(node ast.Node) bool {
 		ce, ok := node.(*ast.CallExpr)
 		if !ok {
 			return true
@@ -917,15 +2017,15 @@ func (f *file) lintErrorStrings() {
 		}
 		f.errorf(str, conf, link(styleGuideBase+"#Error_Strings"), category("errors"), msg)
 		return true
-	})
+	}, (*ast.CallExpr)(nil))
 }
 
 // lintReceiverThis examine reciever names. It argues on
 // not "this" names
 func (f *file) lintReceiverThis() {
-	f.walk(func(n ast.Node) bool {
+	f.visit(func(n ast.Node) bool {
 		fn, ok := n.(*ast.FuncDecl)
-		if !ok || fn.Recv == nil {
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
 			return true
 		}
 		names := fn.Recv.List[0].Names
@@ -937,16 +2037,16 @@ func (f *file) lintReceiverThis() {
 			f.errorf(n, 1, category("naming"), `receiver name should be 'this'`)
 		}
 		return true
-	})
+	}, (*ast.FuncDecl)(nil))
 }
 
 // lintReceiverNames examines receiver names. It complains about inconsistent
 // names used for the same type and names such as "this".
 func (f *file) lintReceiverNames() {
-	typeReceiver := map[string]string{}
-	f.walk(func(n ast.Node) bool {
+	typeReceiver := f.receiverNames
+	f.visit(func(n ast.Node) bool {
 		fn, ok := n.(*ast.FuncDecl)
-		if !ok || fn.Recv == nil {
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
 			return true
 		}
 		names := fn.Recv.List[0].Names
@@ -964,19 +2064,42 @@ func (f *file) lintReceiverNames() {
 			return true
 		}
 		recv := receiverType(fn)
+		if recv == "" {
+			return true
+		}
 		if prev, ok := typeReceiver[recv]; ok && prev != name {
 			f.errorf(n, 1, link(ref), category("naming"), "receiver name %s should be consistent with previous receiver name %s for %s", name, prev, recv)
 			return true
 		}
 		typeReceiver[recv] = name
 		return true
-	})
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintUnusedReceiver examines exported methods.
+// It complains about those that name their receiver without using it;
+// the name should then be omitted, since an underscore is flagged too.
+func (f *file) lintUnusedReceiver() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Recv == nil || len(fn.Recv.List) != 1 || !fn.Name.IsExported() || fn.Body == nil {
+			return true
+		}
+		names := fn.Recv.List[0].Names
+		if len(names) < 1 || isBlank(names[0]) {
+			return true
+		}
+		if findObjRef(fn.Body, names[0].Obj) == nil {
+			f.errorf(names[0], 0.6, link(styleGuideBase+"#Receiver_Names"), category("naming"), "receiver %s is not used in method %s; omit the receiver name", names[0].Name, fn.Name.Name)
+		}
+		return true
+	}, (*ast.FuncDecl)(nil))
 }
 
 // lintIncDec examines statements that increment or decrement a variable.
 // It complains if they don't use x++ or x--.
 func (f *file) lintIncDec() {
-	f.walk(func(n ast.Node) bool {
+	f.visit(func(n ast.Node) bool {
 		as, ok := n.(*ast.AssignStmt)
 		if !ok {
 			return true
@@ -996,15 +2119,18 @@ func (f *file) lintIncDec() {
 		default:
 			return true
 		}
-		f.errorf(as, 0.8, category("unary-op"), "should replace %s with %s%s", f.render(as), f.render(as.Lhs[0]), suffix)
+		fix := func() *SuggestedFix {
+			return f.fix(as.Pos(), as.End(), f.render(as.Lhs[0])+suffix)
+		}
+		f.errorf(as, 0.8, category("unary-op"), fixTitle("Use "+suffix), fix, "should replace %s with %s%s", f.lazyRender(as), f.lazyRender(as.Lhs[0]), suffix)
 		return true
-	})
+	}, (*ast.AssignStmt)(nil))
 }
 
 // lintMakeSlice examines statements that declare and initialize a variable with make.
 // It complains if they are constructing a zero element slice.
 func (f *file) lintMakeSlice() {
-	f.walk(func(n ast.Node) bool {
+	f.visit(func(n ast.Node) bool {
 		as, ok := n.(*ast.AssignStmt)
 		if !ok {
 			return true
@@ -1025,33 +2151,61 @@ func (f *file) lintMakeSlice() {
 		if !ok || at.Len != nil {
 			return true
 		}
-		f.errorf(as, 0.8, category("slice"), `can probably use "var %s %s" instead`, f.render(as.Lhs[0]), f.render(at))
+		should := lazyString(func() string {
+			return fmt.Sprintf("var %s %s", f.render(as.Lhs[0]), f.render(at))
+		})
+		fix := func() *SuggestedFix {
+			return f.fix(as.Pos(), as.End(), should())
+		}
+		f.errorf(as, 0.8, category("slice"), fixTitle("Declare nil slice"), fix, `can probably use "%s" instead`, should)
 		return true
-	})
+	}, (*ast.AssignStmt)(nil))
 }
 
 // lintErrorReturn examines function declarations that return an error.
 // It complains if the error isn't the last parameter.
+// Besides error itself, the error types declared in the package and
+// those listed in Config.ErrorTypeNames count as errors.
 func (f *file) lintErrorReturn() {
-	f.walk(func(n ast.Node) bool {
+	f.visit(func(n ast.Node) bool {
 		fn, ok := n.(*ast.FuncDecl)
 		if !ok || fn.Type.Results == nil {
 			return true
 		}
-		ret := fn.Type.Results.List
+		ret := fieldTypes(fn.Type.Results)
 		if len(ret) <= 1 {
 			return true
 		}
 		// An error return parameter should be the last parameter.
 		// Flag any error parameters found before the last.
-		for _, r := range ret[:len(ret)-1] {
-			if isIdent(r.Type, "error") {
+		for _, typ := range ret[:len(ret)-1] {
+			if f.isErrorType(typ) {
 				f.errorf(fn, 0.9, category("arg-order"), "error should be the last type when returning multiple items")
 				break // only flag one
 			}
 		}
 		return true
-	})
+	}, (*ast.FuncDecl)(nil))
+}
+
+// isErrorType reports whether typ is error, or an error type declared
+// in the package or listed in Config.ErrorTypeNames, or a pointer to one.
+func (f *file) isErrorType(typ ast.Expr) bool {
+	if isIdent(typ, "error") {
+		return true
+	}
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch v := typ.(type) {
+	case *ast.Ident:
+		return f.pkg.errorTypes[v.Name] || f.pkg.errorTypeNames[v.Name]
+	case *ast.SelectorExpr:
+		if x, ok := v.X.(*ast.Ident); ok {
+			return f.pkg.errorTypeNames[x.Name+"."+v.Sel.Name]
+		}
+	}
+	return false
 }
 
 // Check for ignored values returned from function calls. Ignored errors are special case.
@@ -1059,22 +2213,29 @@ func (f *file) lintErrorReturn() {
 // 1. "silently" - when no acceptor is provided for returned error
 // 2. "intentionally" - when acceptor for returned error is "_". Like: "_ := foo()"
 func (f *file) lintIgnoredReturn() {
-	f.walk(func(n ast.Node) bool {
+	fmtName, osName := f.importName("fmt"), f.importName("os")
+	f.visit(func(n ast.Node) bool {
 
 		if expr, ok := n.(*ast.ExprStmt); ok && expr.X != nil {
 			// process simple function call here, with no assignment.
-			fn := extractFuncDecl(expr.X)
+			if call, ok := expr.X.(*ast.CallExpr); ok {
+				if f.isFailingWrite(call, fmtName, osName) {
+					f.errorf(expr, 0.9, category("result-ignore"), "function '%s' returns an error, it should not be silently ignored", f.lazyRender(call.Fun))
+					return true
+				}
+			}
+			fn := f.extractFuncDecl(expr.X)
 			errIndices := extractErrResultIndices(fn)
 			if len(errIndices) > 0 {
 				// check for ignored returned errors first
-				f.errorf(expr, 1.0, category("result-ignore"), "function '%s' returns an error, it should not be silently ignored", fn.Name)
+				f.errorf(expr, 1.0, category("result-ignore"), "function '%s' returns an error, it should not be silently ignored", f.lazyRender(expr.X.(*ast.CallExpr).Fun))
 
 				return true
 			}
 
 			if fn != nil && fn.Type.Results != nil && fn.Type.Results.List != nil && len(fn.Type.Results.List) > 0 {
 				// if no returned errors, than check if there is any returned value that is ignored
-				f.errorf(expr, 0.9, category("result-ignore"), "result of '%s' should not be silently ignored", fn.Name)
+				f.errorf(expr, 0.9, category("result-ignore"), "result of '%s' should not be silently ignored", f.lazyRender(expr.X.(*ast.CallExpr).Fun))
 
 				return true
 			}
@@ -1083,7 +2244,7 @@ func (f *file) lintIgnoredReturn() {
 			// is ignored in statements like "a, b, c := fcall()"
 			// at the moment assignments like "a, b := b, a" are not processed
 			// TODO: do something with "a, b := f1(), f2()" and change if needed
-			fn := extractFuncDecl(asgn.Rhs[0])
+			fn := f.extractFuncDecl(asgn.Rhs[0])
 			errIndices := extractErrResultIndices(fn)
 
 			for _, i := range errIndices {
@@ -1095,38 +2256,184 @@ func (f *file) lintIgnoredReturn() {
 					return true
 				}
 				if ident, ok := asgn.Lhs[i].(*ast.Ident); ok && ident.Name == "_" {
-					f.errorf(asgn, 0.8, category("result-ignore"), "function '%s' returns an error, generally it should not be intentionally ignored", fn.Name)
+					f.errorf(asgn, 0.8, category("result-ignore"), "function '%s' returns an error, generally it should not be intentionally ignored", f.lazyRender(asgn.Rhs[0].(*ast.CallExpr).Fun))
 
 					return true
 				}
 			}
 		}
 		return true
-	})
+	}, (*ast.ExprStmt)(nil), (*ast.AssignStmt)(nil))
 }
 
-// try to extract declaration of fuction from given expression node
-func extractFuncDecl(expr ast.Expr) *ast.FuncDecl {
-	if cExpr, ok := expr.(*ast.CallExpr); ok && cExpr.Fun != nil {
-		if ident, ok := cExpr.Fun.(*ast.Ident); ok && ident.Obj != nil && ident.Obj.Kind == ast.Fun && ident.Obj.Decl != nil {
-			if fDecl, ok := ident.Obj.Decl.(*ast.FuncDecl); ok && fDecl.Type != nil && fDecl.Type.Results != nil && fDecl.Type.Results.List != nil {
-				return fDecl
-			}
+// writeFuncs are the fmt functions that write to an io.Writer
+// and return its error.
+var writeFuncs = map[string]bool{"Fprint": true, "Fprintf": true, "Fprintln": true}
+
+// isFailingWrite reports whether call writes to an io.Writer, like fmt.Fprintf
+// or the Write or Flush method of a writer, and returns an error that
+// shouldn't be ignored.
+// Writes to os.Stdout and os.Stderr, and to writers that never fail,
+// like a bytes.Buffer, don't count.
+// The methods need type information, since many types have a Write or Flush
+// method that doesn't return an error, or one that is always nil.
+func (f *file) isFailingWrite(call *ast.CallExpr, fmtName, osName string) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	var w ast.Expr
+	switch name := sel.Sel.Name; {
+	case fmtName != "" && isIdent(sel.X, fmtName) && writeFuncs[name] && len(call.Args) > 0:
+		w = call.Args[0]
+	case name == "Write" && len(call.Args) == 1, name == "Flush" && len(call.Args) == 0:
+		t := f.typeOf(call)
+		if tuple, ok := t.(*types.Tuple); ok && tuple.Len() > 0 {
+			t = tuple.At(tuple.Len() - 1).Type()
+		}
+		if t == nil || !types.Identical(t, errorType) {
+			return false
+		}
+		w = sel.X
+	default:
+		return false
+	}
+	if osName != "" && (isPkgDot(w, osName, "Stdout") || isPkgDot(w, osName, "Stderr")) {
+		return false
+	}
+	if t := f.typeOf(w); t != nil {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if neverFailingWriters[types.TypeString(t, nil)] {
+			return false
 		}
 	}
+	return true
+}
 
-	return nil
+// neverFailingWriters are the writers whose Write method always returns a nil error.
+var neverFailingWriters = map[string]bool{
+	"bytes.Buffer":    true,
+	"strings.Builder": true,
+	"hash.Hash":       true,
+	"hash.Hash32":     true,
+	"hash.Hash64":     true,
 }
 
-// from given function declaration extract indices of values in return result list which represent "error"
-// Example: for definition of "func foo() (error, int, string, error)" it will return [0, 3]
-func extractErrResultIndices(fn *ast.FuncDecl) (ind []int) {
-	ind = make([]int, 0)
+// try to extract declaration of fuction or method from given expression node.
+// Methods are resolved by the type of their receiver, see localTypeName.
+func (f *file) extractFuncDecl(expr ast.Expr) *ast.FuncDecl {
+	cExpr, ok := expr.(*ast.CallExpr)
+	if !ok || cExpr.Fun == nil {
+		return nil
+	}
+	var fDecl *ast.FuncDecl
+	switch fun := cExpr.Fun.(type) {
+	case *ast.Ident:
+		if fun.Obj != nil && fun.Obj.Kind == ast.Fun && fun.Obj.Decl != nil {
+			fDecl, _ = fun.Obj.Decl.(*ast.FuncDecl)
+		}
+	case *ast.SelectorExpr:
+		if typ := f.localTypeName(fun.X); typ != "" {
+			fDecl = f.pkg.methods[typ+"."+fun.Sel.Name]
+		}
+	}
+	if fDecl != nil && fDecl.Type != nil && fDecl.Type.Results != nil && fDecl.Type.Results.List != nil {
+		return fDecl
+	}
+
+	return nil
+}
+
+// localTypeName returns the name of the type, or the type pointed to, of expr
+// if it may be declared in the package, or "" if it can't be told.
+// Without type information only the variables recognized by declaredType are.
+func (f *file) localTypeName(expr ast.Expr) string {
+	if t := f.typeOf(expr); t != nil {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok && named.Obj().Pkg() == f.pkg.typesPkg {
+			return named.Obj().Name()
+		}
+		return ""
+	}
+	typ := declaredType(expr)
+	for {
+		switch t := typ.(type) {
+		case *ast.StarExpr:
+			typ = t.X
+		case *ast.IndexExpr:
+			typ = t.X
+		case *ast.IndexListExpr:
+			typ = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// declaredType returns the type expr is declared with if it is a variable
+// declared with a type, such as a parameter or receiver, or with a composite
+// literal or make call, or nil if it isn't.
+func declaredType(expr ast.Expr) ast.Expr {
+	id, ok := expr.(*ast.Ident)
+	if !ok || id.Obj == nil || id.Obj.Kind != ast.Var {
+		return nil
+	}
+	var typ ast.Expr
+	switch d := id.Obj.Decl.(type) {
+	case *ast.Field:
+		typ = d.Type
+	case *ast.ValueSpec:
+		typ = d.Type
+		for i, name := range d.Names {
+			if typ == nil && name.Obj == id.Obj && i < len(d.Values) {
+				typ = literalType(d.Values[i])
+			}
+		}
+	case *ast.AssignStmt:
+		if len(d.Lhs) == len(d.Rhs) {
+			for i, lhs := range d.Lhs {
+				if lid, ok := lhs.(*ast.Ident); ok && lid.Obj == id.Obj {
+					typ = literalType(d.Rhs[i])
+				}
+			}
+		}
+	}
+	return typ
+}
+
+// literalType returns the type of the composite literal, or pointer to one,
+// or the type made by the make call expr, or nil if it isn't one of these.
+func literalType(expr ast.Expr) ast.Expr {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = u.X
+	}
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return e.Type
+	case *ast.CallExpr:
+		if isIdent(e.Fun, "make") && len(e.Args) > 0 {
+			return e.Args[0]
+		}
+	}
+	return nil
+}
+
+// from given function declaration extract indices of values in return result list which represent "error"
+// Example: for definition of "func foo() (error, int, string, error)" it will return [0, 3]
+// Each of the names of a field like (a, b int) counts as one result.
+func extractErrResultIndices(fn *ast.FuncDecl) (ind []int) {
+	ind = make([]int, 0)
 	if fn == nil || fn.Type.Results == nil || fn.Type.Results.List == nil {
 		return
 	}
-	for i, field := range fn.Type.Results.List {
-		if fieldIdent, ok := field.Type.(*ast.Ident); ok {
+	for i, typ := range fieldTypes(fn.Type.Results) {
+		if fieldIdent, ok := typ.(*ast.Ident); ok {
 			if fieldIdent.Obj == nil && fieldIdent.Name == "error" {
 				ind = append(ind, i)
 			} else if fieldIdent.Obj != nil && fieldIdent.Obj.Kind == ast.Typ {
@@ -1139,9 +2446,11 @@ func extractErrResultIndices(fn *ast.FuncDecl) (ind []int) {
 }
 
 // lintNamedReturn examines function return values.
-// It complains if any return value named.
+// It complains about each named return value, reporting its position
+// counting each of the names of a field like (a, b int).
+// Blank names don't count as names.
 func (f *file) lintNamedReturn() {
-	f.walk(func(n ast.Node) bool {
+	f.visit(func(n ast.Node) bool {
 		fn, ok := n.(*ast.FuncDecl)
 		if !ok || fn.Type.Results == nil {
 			return true
@@ -1151,60 +2460,3892 @@ func (f *file) lintNamedReturn() {
 		i := 0
 		for _, r := range ret {
 			for _, varName := range r.Names {
-				if f.render(varName) != "" {
+				if !isBlank(varName) {
 					f.errorf(fn, 0.9, category("named-return"), "return value #%d(%q) should not be named", i, varName)
-					i += 1
 				}
+				i++
 			}
 		}
 		return true
-	})
+	}, (*ast.FuncDecl)(nil))
 }
 
-func receiverType(fn *ast.FuncDecl) string {
-	switch e := fn.Recv.List[0].Type.(type) {
-	case *ast.Ident:
-		return e.Name
-	case *ast.StarExpr:
-		return e.X.(*ast.Ident).Name
+// lintLoopVarCapture examines go and defer statements inside loops.
+// It complains if a func literal refers to a loop variable instead of
+// receiving it as an argument. Before Go 1.22 loop variables are shared
+// between iterations, so such a closure usually sees a later value.
+func (f *file) lintLoopVarCapture() {
+	f.visit(func(n ast.Node) bool {
+		var vars []*ast.Ident
+		var body *ast.BlockStmt
+		switch v := n.(type) {
+		case *ast.RangeStmt:
+			if v.Tok != token.DEFINE {
+				return true
+			}
+			for _, e := range []ast.Expr{v.Key, v.Value} {
+				if id, ok := e.(*ast.Ident); ok && !isBlank(id) {
+					vars = append(vars, id)
+				}
+			}
+			body = v.Body
+		case *ast.ForStmt:
+			as, ok := v.Init.(*ast.AssignStmt)
+			if !ok || as.Tok != token.DEFINE {
+				return true
+			}
+			for _, e := range as.Lhs {
+				if id, ok := e.(*ast.Ident); ok && !isBlank(id) {
+					vars = append(vars, id)
+				}
+			}
+			body = v.Body
+		default:
+			return true
+		}
+		if len(vars) == 0 {
+			return true
+		}
+
+		ast.Inspect(body, func(n ast.Node) bool {
+			var call *ast.CallExpr
+			var stmt string
+			switch v := n.(type) {
+			case *ast.GoStmt:
+				call, stmt = v.Call, "go"
+			case *ast.DeferStmt:
+				call, stmt = v.Call, "defer"
+			default:
+				return true
+			}
+			lit, ok := call.Fun.(*ast.FuncLit)
+			if !ok {
+				return true
+			}
+			for _, lv := range vars {
+				if ref := findObjRef(lit.Body, lv.Obj); ref != nil {
+					f.errorf(ref, 0.9, link("https://golang.org/doc/faq#closures_and_goroutines"), category("concurrency"), "loop variable %s captured by func literal in %s statement; pass it as an argument instead", lv.Name, stmt)
+				}
+			}
+			// Nested statements are covered by the references found above.
+			return false
+		})
+		return true
+	}, (*ast.RangeStmt)(nil), (*ast.ForStmt)(nil))
+}
+
+// lintHardcodedSecret examines variables and constants whose names suggest credentials.
+// It complains if they are assigned a non-empty string literal.
+func (f *file) lintHardcodedSecret() {
+	re := f.pkg.secretNameRE
+	if re == nil {
+		return
+	}
+	check := func(id *ast.Ident, value ast.Expr) {
+		if isBlank(id) || !re.MatchString(id.Name) {
+			return
+		}
+		lit, ok := value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+		s, _ := strconv.Unquote(lit.Value) // can assume well-formed Go
+		if s == "" {
+			return
+		}
+		for _, p := range f.config.SecretPlaceholders {
+			if strings.EqualFold(s, p) {
+				return
+			}
+		}
+		f.errorf(lit, 0.3, category("security"), "%s looks like a hardcoded secret; load it from configuration or the environment instead", id.Name)
 	}
-	panic(fmt.Sprintf("unknown method receiver AST node type %T", fn.Recv.List[0].Type))
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.ValueSpec:
+			if len(v.Names) != len(v.Values) {
+				return true
+			}
+			for i, id := range v.Names {
+				check(id, v.Values[i])
+			}
+		case *ast.AssignStmt:
+			if len(v.Lhs) != len(v.Rhs) {
+				return true
+			}
+			for i, lhs := range v.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					check(id, v.Rhs[i])
+				}
+			}
+		}
+		return true
+	}, (*ast.ValueSpec)(nil), (*ast.AssignStmt)(nil))
 }
 
-func (f *file) walk(fn func(ast.Node) bool) {
-	ast.Walk(walker(fn), f.f)
+var securityFuncNameRE = regexp.MustCompile(`(?i)token|secret|nonce|salt|passw(or)?d`)
+
+// lintInsecureRand examines functions whose names suggest security-sensitive work.
+// It complains if they use math/rand, which is not cryptographically secure.
+func (f *file) lintInsecureRand() {
+	randName := f.importName("math/rand")
+	if randName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			return v.Body != nil && securityFuncNameRE.MatchString(v.Name.Name)
+		case *ast.CallExpr:
+			fn, ok := f.decl.(*ast.FuncDecl)
+			if !ok {
+				return true
+			}
+			if sel, ok := v.Fun.(*ast.SelectorExpr); ok && isIdent(sel.X, randName) {
+				f.errorf(v, 0.3, category("security"), "math/rand (%s.%s) used in security-sensitive function %s; use crypto/rand instead", randName, sel.Sel.Name, fn.Name.Name)
+			}
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.CallExpr)(nil))
 }
 
-func (f *file) render(x interface{}) string {
-	var buf bytes.Buffer
-	if err := printer.Fprint(&buf, f.fset, x); err != nil {
-		panic(err)
+var deprecationRE = regexp.MustCompile(`(?i)\bdeprecated\b|\bdo not use\b`)
+
+// lintDeprecatedComment examines doc comments that mention deprecation.
+// It complains if they don't contain a paragraph starting with "Deprecated: ",
+// which is the form recognized by go doc, gopls and other tools.
+func (f *file) lintDeprecatedComment() {
+	check := func(doc *ast.CommentGroup) {
+		if doc == nil {
+			return
+		}
+		s := doc.Text()
+		if !deprecationRE.MatchString(s) {
+			return
+		}
+		prev := ""
+		for _, line := range strings.Split(s, "\n") {
+			if prev == "" && strings.HasPrefix(line, "Deprecated: ") {
+				return
+			}
+			prev = line
+		}
+		f.errorf(doc, 0.7, link("https://go.dev/wiki/Deprecated"), category("comments"), `deprecation notice should be a paragraph of the form "Deprecated: ..."`)
 	}
-	return buf.String()
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			check(v.Doc)
+		case *ast.GenDecl:
+			check(v.Doc)
+		case *ast.TypeSpec:
+			check(v.Doc)
+		case *ast.ValueSpec:
+			check(v.Doc)
+		case *ast.Field:
+			check(v.Doc)
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.GenDecl)(nil), (*ast.TypeSpec)(nil), (*ast.ValueSpec)(nil), (*ast.Field)(nil))
 }
 
-func (f *file) debugRender(x interface{}) string {
-	var buf bytes.Buffer
-	if err := ast.Fprint(&buf, f.fset, x, nil); err != nil {
-		panic(err)
+// lintTestSignatures examines test, benchmark and example functions in test files.
+// It complains if they have the wrong signature, or if go test would silently
+// ignore them because the name continues with a lowercase letter.
+func (f *file) lintTestSignatures() {
+	if !f.isTest() {
+		return
 	}
-	return buf.String()
+	testing := f.importName("testing")
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Recv != nil {
+			return false
+		}
+		name := fn.Name.Name
+		var prefix, arg string
+		switch {
+		case name == "TestMain":
+			prefix, arg = "Test", "M"
+		case strings.HasPrefix(name, "Test"):
+			prefix, arg = "Test", "T"
+		case strings.HasPrefix(name, "Benchmark"):
+			prefix, arg = "Benchmark", "B"
+		case strings.HasPrefix(name, "Example"):
+			if fn.Type.Params.NumFields() > 0 || fn.Type.Results.NumFields() > 0 {
+				f.errorf(fn, 0.9, category("testing"), "example function %s should have no parameters and no results", name)
+			}
+			return false
+		default:
+			return false
+		}
+		if next, _ := utf8.DecodeRuneInString(name[len(prefix):]); unicode.IsLower(next) {
+			f.errorf(fn.Name, 0.9, category("testing"), "%s is not run by go test because the character after %s is lowercase", name, prefix)
+			return false
+		}
+		if !isTestingParams(fn.Type, testing, arg) {
+			f.errorf(fn, 0.9, category("testing"), "%s should have signature func(*testing.%s)", name, arg)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// isTestingParams reports whether ft is func(*<testing>.<arg>) with no results.
+func isTestingParams(ft *ast.FuncType, testing, arg string) bool {
+	if ft.Results.NumFields() > 0 || ft.Params.NumFields() != 1 {
+		return false
+	}
+	star, ok := ft.Params.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	if testing == "" {
+		// Without the import only the unqualified name can be checked.
+		sel, ok := star.X.(*ast.SelectorExpr)
+		return ok && isIdent(sel.Sel, arg)
+	}
+	return isPkgDot(star.X, testing, arg)
 }
 
-// walker adapts a function to satisfy the ast.Visitor interface.
-// The function return whether the walk should proceed into the node's children.
-type walker func(ast.Node) bool
+var integrityFuncNameRE = regexp.MustCompile(`(?i)token|secret|nonce|salt|passw(or)?d|auth|sign|verify|hmac|integrity`)
 
-func (w walker) Visit(node ast.Node) ast.Visitor {
-	if w(node) {
-		return w
+// lintWeakHash examines functions whose names suggest hashing for integrity or authentication.
+// It complains if they use MD5 or SHA-1. Both are fine for checksums,
+// so this is only a hint.
+func (f *file) lintWeakHash() {
+	algs := map[string]string{}
+	for path, alg := range map[string]string{"crypto/md5": "MD5", "crypto/sha1": "SHA-1"} {
+		if name := f.importName(path); name != "" {
+			algs[name] = alg
+		}
 	}
-	return nil
+	if len(algs) == 0 {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			return v.Body != nil && integrityFuncNameRE.MatchString(v.Name.Name)
+		case *ast.SelectorExpr:
+			fn, ok := f.decl.(*ast.FuncDecl)
+			if !ok {
+				return true
+			}
+			id, ok := v.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if alg, ok := algs[id.Name]; ok && (v.Sel.Name == "New" || v.Sel.Name == "Sum") {
+				f.errorf(v, 0.2, category("security"), "weak hash %s (%s.%s) used in %s; use SHA-256 (crypto/sha256) for security purposes", alg, id.Name, v.Sel.Name, fn.Name.Name)
+			}
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.SelectorExpr)(nil))
 }
 
-func isIdent(expr ast.Expr, ident string) bool {
-	id, ok := expr.(*ast.Ident)
-	return ok && id.Name == ident
+// lintInsecureTLS examines tls.Config composite literals.
+// It complains if they disable certificate verification.
+func (f *file) lintInsecureTLS() {
+	tlsName := f.importName("crypto/tls")
+	if tlsName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		cl, ok := n.(*ast.CompositeLit)
+		if !ok || !isPkgDot(cl.Type, tlsName, "Config") {
+			return true
+		}
+		for _, elt := range cl.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if ok && isIdent(kv.Key, "InsecureSkipVerify") && isIdent(kv.Value, "true") {
+				f.errorf(kv, 0.6, category("security"), "InsecureSkipVerify disables TLS certificate verification; connections are open to man-in-the-middle attacks")
+			}
+		}
+		return true
+	}, (*ast.CompositeLit)(nil))
+}
+
+// lintExecCommand examines exec.Command and exec.CommandContext calls.
+// It complains if the program name is a string literal containing a space,
+// since it is not split into a program and its arguments.
+func (f *file) lintExecCommand() {
+	execName := f.importName("os/exec")
+	if execName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		var arg int
+		switch {
+		case isPkgDot(ce.Fun, execName, "Command"):
+			arg = 0
+		case isPkgDot(ce.Fun, execName, "CommandContext"):
+			arg = 1
+		default:
+			return true
+		}
+		if len(ce.Args) <= arg {
+			return true
+		}
+		lit, ok := ce.Args[arg].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		s, _ := strconv.Unquote(lit.Value) // can assume well-formed Go
+		if strings.Contains(s, " ") {
+			f.errorf(lit, 0.7, category("suspicious"), "%s.%s runs %q as the program name; pass the arguments separately", execName, ce.Fun.(*ast.SelectorExpr).Sel.Name, s)
+		}
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// lintFilepathJoin examines filepath.Join calls.
+// It complains about literal elements after the first one that are absolute
+// or contain "..", which may escape the base directory.
+func (f *file) lintFilepathJoin() {
+	fpName := f.importName("path/filepath")
+	if fpName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce, ok := n.(*ast.CallExpr)
+		if !ok || !isPkgDot(ce.Fun, fpName, "Join") || len(ce.Args) < 2 {
+			return true
+		}
+		for _, arg := range ce.Args[1:] {
+			lit, ok := arg.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			s, _ := strconv.Unquote(lit.Value) // can assume well-formed Go
+			if strings.HasPrefix(s, "/") || strings.Contains(s, "..") {
+				f.errorf(lit, 0.2, category("security"), "suspicious element %q in %s.Join; it may not stay within the base path", s, fpName)
+			}
+		}
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// sprintfReplacements maps single-verb formats to the direct form of
+// fmt.Sprintf(format, x) and the type x must have for it.
+var sprintfReplacements = map[string]struct {
+	repl string
+	kind types.BasicKind
+}{
+	"%s": {"%s", types.String},
+	"%d": {"strconv.Itoa(%s)", types.Int},
+	"%t": {"strconv.FormatBool(%s)", types.Bool},
+	"%q": {"strconv.Quote(%s)", types.String},
+	"%c": {"string(%s)", types.Int32},
+}
+
+// lintUselessSprintf examines fmt.Sprintf calls with a single verb and a single argument.
+// It complains if the call could be a plain conversion. The argument must
+// have exactly the type the conversion takes. Without Config.TypeCheck,
+// only literal arguments such as 5 or "x" are recognized, by their default type.
+func (f *file) lintUselessSprintf() {
+	fmtName := f.importName("fmt")
+	if fmtName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce, ok := n.(*ast.CallExpr)
+		if !ok || !isPkgDot(ce.Fun, fmtName, "Sprintf") || len(ce.Args) != 2 {
+			return true
+		}
+		lit, ok := ce.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		format, _ := strconv.Unquote(lit.Value) // can assume well-formed Go
+		r, ok := sprintfReplacements[format]
+		if !ok {
+			return true
+		}
+		if t := f.typeOf(ce.Args[1]); t != nil {
+			if !types.Identical(t, types.Typ[r.kind]) {
+				return true
+			}
+		} else if kind, ok := untypedKind(ce.Args[1]); !ok || kind != r.kind {
+			return true
+		}
+		should := lazyString(func() string {
+			return fmt.Sprintf(r.repl, f.render(ce.Args[1]))
+		})
+		f.errorf(ce, 0.7, category("performance"), "should replace %s with %s", f.lazyRender(ce), should)
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// untypedKind returns the default type of expr if it is a literal
+// or one of the predeclared constants true and false.
+func untypedKind(expr ast.Expr) (types.BasicKind, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.INT:
+			return types.Int, true
+		case token.STRING:
+			return types.String, true
+		case token.CHAR:
+			return types.Int32, true
+		}
+	case *ast.Ident:
+		if (e.Name == "true" || e.Name == "false") && e.Obj == nil {
+			return types.Bool, true
+		}
+	}
+	return 0, false
+}
+
+// fileModeArgs maps functions in package os to the index of their file mode argument.
+var fileModeArgs = map[string]int{
+	"OpenFile":  2,
+	"Mkdir":     1,
+	"MkdirAll":  1,
+	"WriteFile": 2,
+}
+
+// lintFilePermissions examines calls that create files and directories.
+// It complains if their mode literal is world-writable.
+func (f *file) lintFilePermissions() {
+	osName := f.importName("os")
+	if osName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := ce.Fun.(*ast.SelectorExpr)
+		if !ok || !isIdent(sel.X, osName) {
+			return true
+		}
+		i, ok := fileModeArgs[sel.Sel.Name]
+		if !ok || len(ce.Args) <= i {
+			return true
+		}
+		lit, ok := ce.Args[i].(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return true
+		}
+		mode, err := strconv.ParseUint(strings.Replace(lit.Value, "_", "", -1), 0, 32)
+		if err != nil || mode&0002 == 0 {
+			return true
+		}
+		f.errorf(lit, 0.4, category("security"), "%s.%s with mode %s is world-writable (others: %s); use a more restrictive mode", osName, sel.Sel.Name, lit.Value, permString(mode))
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// permString returns the ls-style permissions ("rwx") for the "others" bits of mode.
+func permString(mode uint64) string {
+	b := []byte("---")
+	for i, c := range "rwx" {
+		if mode&(4>>uint(i)) != 0 {
+			b[i] = byte(c)
+		}
+	}
+	return string(b)
+}
+
+// lintGetterNames examines exported function and method names.
+// It complains if they start with a Get prefix, which Go getters don't use.
+func (f *file) lintGetterNames() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		name := fn.Name.Name
+		if !ast.IsExported(name) || !strings.HasPrefix(name, "Get") {
+			return false
+		}
+		// Only a new word after "Get" makes it a prefix, so "Getwd" is fine.
+		if next, _ := utf8.DecodeRuneInString(name[len("Get"):]); !unicode.IsUpper(next) {
+			return false
+		}
+		thing := "func"
+		if fn.Recv != nil {
+			thing = "method"
+		}
+		f.errorf(fn.Name, 0.8, link("http://golang.org/doc/effective_go.html#Getters"), category("naming"), "%s %s should be %s; getters don't use a Get prefix", thing, name, name[len("Get"):])
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+var errorInterface = errorType.Underlying().(*types.Interface)
+
+// lintConcreteErrorReturn examines function results.
+// It complains about results that are pointers to error types instead of error,
+// since a nil pointer returned as an error is not a nil error.
+// Without type information only error types declared in the package are recognized.
+// Constructors (New...) are skipped, as they return a concrete type by design.
+func (f *file) lintConcreteErrorReturn() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Type.Results == nil {
+			return false
+		}
+		if name := fn.Name.Name; strings.HasPrefix(name, "New") || strings.HasPrefix(name, "new") {
+			return false
+		}
+		for _, r := range fn.Type.Results.List {
+			star, ok := r.Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			conf := 0.6
+			if t := f.typeOf(star); t != nil {
+				if !types.Implements(t, errorInterface) {
+					continue
+				}
+				conf = 0.9
+			} else if id, ok := star.X.(*ast.Ident); !ok || !f.pkg.errorTypes[id.Name] {
+				continue
+			}
+			typ := f.lazyRender(star)
+			f.errorf(r.Type, conf, link("https://golang.org/doc/faq#nil_error"), category("errors"), "%s should return error instead of %s; a nil %s returned as an error is not a nil error", fn.Name.Name, typ, typ)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintSubtestT examines subtests started with t.Run in test files.
+// It complains if the subtest function uses the outer *testing.T (or *testing.B)
+// instead of its own parameter, which defeats subtest isolation.
+func (f *file) lintSubtestT() {
+	if !f.isTest() {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce, ok := n.(*ast.CallExpr)
+		if !ok || len(ce.Args) != 2 {
+			return true
+		}
+		sel, ok := ce.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Run" {
+			return true
+		}
+		outer, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		lit, ok := ce.Args[1].(*ast.FuncLit)
+		if !ok || lit.Type.Params.NumFields() != 1 {
+			return true
+		}
+		param := lit.Type.Params.List[0]
+		star, ok := param.Type.(*ast.StarExpr)
+		if !ok || len(param.Names) != 1 {
+			return true
+		}
+		if ts, ok := star.X.(*ast.SelectorExpr); !ok || (ts.Sel.Name != "T" && ts.Sel.Name != "B") {
+			return true
+		}
+		if ref := findObjRef(lit.Body, outer.Obj); ref != nil {
+			f.errorf(ref, 0.4, category("testing"), "subtest uses the outer %s instead of its own parameter %s", outer.Name, param.Names[0].Name)
+		}
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+var tableNameRE = regexp.MustCompile(`(?i)^(tt|tcs|table|.*tests|.*cases)$`)
+
+// lintTableTest examines loops over test tables in test functions.
+// It complains if the loop body doesn't run each case as a subtest with t.Run.
+func (f *file) lintTableTest() {
+	if !f.isTest() {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			return v.Body != nil && strings.HasPrefix(v.Name.Name, "Test")
+		case *ast.RangeStmt:
+			if _, ok := f.decl.(*ast.FuncDecl); !ok {
+				return true
+			}
+			table, ok := v.X.(*ast.Ident)
+			if !ok || !tableNameRE.MatchString(table.Name) {
+				return true
+			}
+			hasRun := false
+			ast.Inspect(v.Body, func(n ast.Node) bool {
+				if ce, ok := n.(*ast.CallExpr); ok {
+					if sel, ok := ce.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Run" {
+						hasRun = true
+					}
+				}
+				return !hasRun
+			})
+			if !hasRun {
+				f.errorf(v, 0.2, category("testing"), "table-driven test loop over %s doesn't use t.Run; consider running each case as a named subtest", table.Name)
+			}
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.RangeStmt)(nil))
+}
+
+// directiveRE matches comment directives such as "//go:generate" or "//lint:ignore",
+// which must not have a space after the slashes.
+var directiveRE = regexp.MustCompile(`^//([a-z0-9]+:[a-z0-9]|nolint|export |extern |line )`)
+
+// lintCommentSpacing examines line comments.
+// It complains if the text doesn't start with a space after the slashes.
+// Directives and "///" comments are not flagged.
+func (f *file) lintCommentSpacing() {
+	for _, cg := range f.f.Comments {
+		if !f.inScope(cg.Pos()) {
+			continue
+		}
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, "//") || len(c.Text) == 2 {
+				continue
+			}
+			switch c.Text[2] {
+			case ' ', '\t', '/':
+				continue
+			}
+			if directiveRE.MatchString(c.Text) {
+				continue
+			}
+			pos := c.Pos() + 2
+			f.errorf(c, 0.7, category("comments"), fixTitle("Add space after //"), f.fix(pos, pos, " "), "comment should have a space after //")
+		}
+	}
+}
+
+// lintDuplicateCase examines switch and type switch statements.
+// It complains about case expressions that already appear in an earlier case,
+// since the later one can never be selected.
+func (f *file) lintDuplicateCase() {
+	check := func(body *ast.BlockStmt, thing string) {
+		seen := make(map[string]token.Pos)
+		for _, stmt := range body.List {
+			cc, ok := stmt.(*ast.CaseClause)
+			if !ok {
+				continue
+			}
+			for _, e := range cc.List {
+				s := f.render(e)
+				if prev, ok := seen[s]; ok {
+					f.errorf(e, 0.9, category("control-flow"), "duplicate %s %s in switch; it is already handled at line %d", thing, s, f.fset.Position(prev).Line)
+					continue
+				}
+				seen[s] = e.Pos()
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.SwitchStmt:
+			check(v.Body, "case")
+		case *ast.TypeSwitchStmt:
+			check(v.Body, "type case")
+		}
+		return true
+	}, (*ast.SwitchStmt)(nil), (*ast.TypeSwitchStmt)(nil))
+}
+
+// lintExportedSentinels examines exported functions that return package-level error variables.
+// It complains if the variable is unexported, since callers can't compare against it with errors.Is.
+func (f *file) lintExportedSentinels() {
+	f.visitExportedReturns(func(fn *ast.FuncDecl, ret *ast.ReturnStmt) {
+		for _, res := range ret.Results {
+			if id := f.unexportedSentinel(res); id != nil {
+				f.errorf(id, 0.6, category("errors"), "exported %s returns unexported error %s; callers can't check for it with errors.Is, consider exporting it", fn.Name.Name, id.Name)
+			}
+		}
+	})
+}
+
+// lintUnexportedSentinel examines exported functions that return errors made with fmt.Errorf,
+// wrapping a package-level error variable with %w.
+// It complains if the variable is unexported. Whether callers are meant to check
+// for a wrapped error is less clear than for a returned one, hence the low confidence.
+func (f *file) lintUnexportedSentinel() {
+	fmtName := f.importName("fmt")
+	f.visitExportedReturns(func(fn *ast.FuncDecl, ret *ast.ReturnStmt) {
+		for _, res := range ret.Results {
+			ce, ok := res.(*ast.CallExpr)
+			if !ok || !isPkgDot(ce.Fun, fmtName, "Errorf") || len(ce.Args) < 2 {
+				continue
+			}
+			if lit, ok := ce.Args[0].(*ast.BasicLit); !ok || !strings.Contains(lit.Value, "%w") {
+				continue
+			}
+			for _, arg := range ce.Args[1:] {
+				if id := f.unexportedSentinel(arg); id != nil {
+					f.errorf(id, 0.3, category("errors"), "exported %s returns an error wrapping unexported error %s; callers can't check for it with errors.Is, consider exporting it", fn.Name.Name, id.Name)
+				}
+			}
+		}
+	})
+}
+
+// visitExportedReturns calls fn for each return statement of an exported function or method.
+// Returns in func literals are skipped, since they don't return from the function.
+func (f *file) visitExportedReturns(fn func(*ast.FuncDecl, *ast.ReturnStmt)) {
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			if v.Body == nil || !ast.IsExported(v.Name.Name) {
+				return false
+			}
+			return v.Recv == nil || ast.IsExported(receiverType(v))
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			fn(f.decl.(*ast.FuncDecl), v)
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil), (*ast.ReturnStmt)(nil))
+}
+
+// unexportedSentinel returns the unexported package-level error variable expr refers to, if any.
+func (f *file) unexportedSentinel(expr ast.Expr) *ast.Ident {
+	id, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	vs, ok := f.pkg.sentinels[id.Name]
+	if !ok || ast.IsExported(id.Name) {
+		return nil
+	}
+	// An unresolved identifier may refer to a declaration in another file.
+	if id.Obj != nil && id.Obj.Decl != vs {
+		return nil
+	}
+	return id
+}
+
+// lintMultipleBlankLines examines function bodies.
+// It complains about runs of more than Config.MaxBlankLines blank lines,
+// at the first blank line over the limit.
+// Blank lines in multi-line string literals and comments don't count.
+func (f *file) lintMultipleBlankLines() {
+	max := f.config.MaxBlankLines
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body == nil {
+			return false
+		}
+		tf := f.fset.File(fn.Body.Pos())
+
+		inside := make(map[int]bool) // lines inside a literal or comment
+		skip := func(pos, end token.Pos) {
+			for l := tf.Line(pos) + 1; l <= tf.Line(end); l++ {
+				inside[l] = true
+			}
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				skip(lit.Pos(), lit.End())
+			}
+			return true
+		})
+		for _, cg := range f.commentsAfter(fn.Body.Lbrace + 1) {
+			if cg.End() >= fn.Body.Rbrace {
+				break
+			}
+			skip(cg.Pos(), cg.End())
+		}
+		blank := func(l int) bool {
+			start, end := tf.Offset(tf.LineStart(l)), tf.Offset(tf.LineStart(l+1))
+			return !inside[l] && len(bytes.TrimSpace(f.src[start:end])) == 0
+		}
+
+		// The lines strictly between the braces.
+		first, last := tf.Line(fn.Body.Lbrace)+1, tf.Line(fn.Body.Rbrace)-1
+		run := 0
+		for l := first; l <= last; l++ {
+			if !blank(l) {
+				run = 0
+				continue
+			}
+			run++
+			if run != max+1 {
+				continue
+			}
+			end := l + 1
+			for end <= last && blank(end) {
+				end++
+			}
+			pos := tf.LineStart(l)
+			f.errorf(posNode(pos), 0.3, category("formatting"), fixTitle("Remove blank lines"), f.fix(pos, tf.LineStart(end), ""), "too many consecutive blank lines in function body; use at most %d", max)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintDeadMake examines variables declared with the result of make.
+// It complains if the variable is reassigned before it is used,
+// which wastes the allocation. Only the statements that follow the
+// declaration in the same block are examined.
+func (f *file) lintDeadMake() {
+	check := func(list []ast.Stmt) {
+		for i, stmt := range list {
+			id, call := madeVar(stmt)
+			if id == nil || id.Obj == nil {
+				continue
+			}
+			for _, next := range list[i+1:] {
+				if overwrites(next, id.Obj) {
+					f.errorf(call, 0.6, category("performance"), "%s is overwritten at line %d before it is used, so this make is wasted", id.Name, f.fset.Position(next.Pos()).Line)
+					break
+				}
+				if findObjRef(next, id.Obj) != nil {
+					break
+				}
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.BlockStmt:
+			check(v.List)
+		case *ast.CaseClause:
+			check(v.Body)
+		case *ast.CommClause:
+			check(v.Body)
+		}
+		return true
+	}, (*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil))
+}
+
+// madeVar returns the variable declared by stmt and the make call it is
+// initialized with, if stmt is "x := make(...)" or "var x = make(...)".
+func madeVar(stmt ast.Stmt) (*ast.Ident, *ast.CallExpr) {
+	id, rhs := definedVar(stmt)
+	call, ok := rhs.(*ast.CallExpr)
+	if !ok || !isIdent(call.Fun, "make") {
+		return nil, nil
+	}
+	return id, call
+}
+
+// definedVar returns the variable declared by stmt and the value it is
+// initialized with, if stmt is "x := value" or "var x = value".
+func definedVar(stmt ast.Stmt) (*ast.Ident, ast.Expr) {
+	var lhs, rhs ast.Expr
+	switch s := stmt.(type) {
+	case *ast.AssignStmt:
+		if s.Tok != token.DEFINE || len(s.Lhs) != 1 || len(s.Rhs) != 1 {
+			return nil, nil
+		}
+		lhs, rhs = s.Lhs[0], s.Rhs[0]
+	case *ast.DeclStmt:
+		gd := s.Decl.(*ast.GenDecl)
+		if gd.Tok != token.VAR || len(gd.Specs) != 1 {
+			return nil, nil
+		}
+		vs := gd.Specs[0].(*ast.ValueSpec)
+		if len(vs.Names) != 1 || len(vs.Values) != 1 {
+			return nil, nil
+		}
+		lhs, rhs = vs.Names[0], vs.Values[0]
+	default:
+		return nil, nil
+	}
+	id, ok := lhs.(*ast.Ident)
+	if !ok || isBlank(id) {
+		return nil, nil
+	}
+	return id, rhs
+}
+
+// overwrites reports whether stmt assigns to the variable obj
+// without otherwise referring to it.
+func overwrites(stmt ast.Stmt, obj *ast.Object) bool {
+	as, ok := stmt.(*ast.AssignStmt)
+	if !ok || as.Tok != token.ASSIGN {
+		return false
+	}
+	found := false
+	for _, lhs := range as.Lhs {
+		if id, ok := lhs.(*ast.Ident); ok && id.Obj == obj {
+			found = true
+		} else if findObjRef(lhs, obj) != nil {
+			return false
+		}
+	}
+	for _, rhs := range as.Rhs {
+		if findObjRef(rhs, obj) != nil {
+			return false
+		}
+	}
+	return found
+}
+
+// builtinKinds maps the names of the predeclared identifiers to what they are.
+var builtinKinds = func() map[string]string {
+	m := make(map[string]string)
+	for _, name := range types.Universe.Names() {
+		switch types.Universe.Lookup(name).(type) {
+		case *types.Builtin:
+			m[name] = "function"
+		case *types.TypeName:
+			m[name] = "type"
+		case *types.Const:
+			m[name] = "constant"
+		case *types.Nil:
+			m[name] = "value"
+		}
+	}
+	return m
+}()
+
+// lintBuiltinShadow examines variable, constant, receiver, parameter, type parameter
+// and result declarations.
+// It complains if they use the name of a builtin, such as len or string,
+// which then can't be used in their scope.
+func (f *file) lintBuiltinShadow() { f.builtinShadow(0.6) }
+
+// lintShadowBuiltin is lintBuiltinShadow with the confidence of 0.8
+// Config.ShadowBuiltin asks for, so the problems are reported with the
+// default Config.MinConfidence.
+func (f *file) lintShadowBuiltin() { f.builtinShadow(0.8) }
+
+// builtinShadow does the work of lintBuiltinShadow and lintShadowBuiltin,
+// reporting problems with the given confidence.
+func (f *file) builtinShadow(confidence float64) {
+	check := func(id *ast.Ident, thing string) {
+		if kind, ok := builtinKinds[id.Name]; ok {
+			f.errorf(id, confidence, category("shadow"), "%s %s shadows the builtin %s %s", thing, id.Name, kind, id.Name)
+		}
+	}
+	checkList := func(fl *ast.FieldList, thing string) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			for _, id := range field.Names {
+				check(id, thing)
+			}
+		}
+	}
+	checkFunc := func(ft *ast.FuncType) {
+		checkList(ft.TypeParams, "type parameter")
+		checkList(ft.Params, "parameter")
+		checkList(ft.Results, "result")
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			if v.Tok != token.DEFINE {
+				return true
+			}
+			for _, lhs := range v.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok {
+					check(id, "var")
+				}
+			}
+		case *ast.RangeStmt:
+			if v.Tok != token.DEFINE {
+				return true
+			}
+			for _, e := range []ast.Expr{v.Key, v.Value} {
+				if id, ok := e.(*ast.Ident); ok {
+					check(id, "range var")
+				}
+			}
+		case *ast.GenDecl:
+			if v.Tok != token.VAR && v.Tok != token.CONST {
+				return true
+			}
+			for _, spec := range v.Specs {
+				for _, id := range spec.(*ast.ValueSpec).Names {
+					check(id, strings.ToLower(v.Tok.String()))
+				}
+			}
+		case *ast.TypeSpec:
+			checkList(v.TypeParams, "type parameter")
+		case *ast.FuncDecl:
+			checkList(v.Recv, "receiver")
+			checkFunc(v.Type)
+		case *ast.FuncLit:
+			checkFunc(v.Type)
+		}
+		return true
+	}, (*ast.AssignStmt)(nil), (*ast.RangeStmt)(nil), (*ast.GenDecl)(nil), (*ast.TypeSpec)(nil), (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))
+}
+
+// typeParamNames maps names that only repeat a basic type to the types they repeat.
+var typeParamNames = map[string]string{
+	"str":     "string",
+	"flag":    "bool",
+	"boolean": "bool",
+	"num":     "int",
+	"number":  "int",
+	"integer": "int",
+	"float":   "float64",
+}
+
+// lintParamNameEqualsType examines the parameters of functions.
+// It complains, as a hint, about names that only repeat the parameter's basic type,
+// such as str string, since a short or descriptive name says more.
+// Any other name is assumed to be descriptive.
+func (f *file) lintParamNameEqualsType() {
+	check := func(ft *ast.FuncType) {
+		for _, field := range ft.Params.List {
+			typ, ok := field.Type.(*ast.Ident)
+			if !ok || builtinKinds[typ.Name] != "type" {
+				continue
+			}
+			for _, id := range field.Names {
+				name := strings.ToLower(id.Name)
+				if name != typ.Name && typeParamNames[name] != typ.Name {
+					continue
+				}
+				f.errorf(id, 0.2, category("naming"), "parameter %s only repeats its type %s; use a descriptive name or just %s", id.Name, typ.Name, typ.Name[:1])
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			check(v.Type)
+		case *ast.FuncLit:
+			check(v.Type)
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))
+}
+
+// lintResultCount examines function declarations.
+// It complains if they return more than Config.MaxResults values,
+// not counting an error as the last one with Config.MaxResultsIgnoreError.
+func (f *file) lintResultCount() {
+	max := f.config.MaxResults
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		ret := fieldTypes(fn.Type.Results)
+		count := len(ret)
+		if f.config.MaxResultsIgnoreError && count > 0 && f.isErrorType(ret[count-1]) {
+			count--
+		}
+		if count > max {
+			f.errorf(fn.Name, 0.6, category("api-design"), "%s returns %d values, more than %d; consider returning a struct", fn.Name.Name, len(ret), max)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintTypeParams examines generic function and type declarations.
+// It complains if they have more than Config.MaxTypeParams type parameters.
+func (f *file) lintTypeParams() {
+	max := f.config.MaxTypeParams
+	f.visit(func(n ast.Node) bool {
+		var name *ast.Ident
+		var tparams *ast.FieldList
+		thing := "type"
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			name, tparams, thing = v.Name, v.Type.TypeParams, "func"
+		case *ast.TypeSpec:
+			name, tparams = v.Name, v.TypeParams
+		}
+		if count := tparams.NumFields(); count > max {
+			f.errorf(name, 0.3, category("generics"), "%s %s has %d type parameters, more than %d; consider fewer or more descriptive ones", thing, name.Name, count, max)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil), (*ast.TypeSpec)(nil))
+}
+
+// lintLargeParamCopy examines the receivers and parameters of functions and func literals.
+// It complains about those passing by value a struct with more than
+// Config.LargeParamFields fields, since the struct is copied on each call.
+// With Config.TypeCheck, any struct type is recognized, including those of
+// other packages; without it, only the struct types declared in the same file.
+func (f *file) lintLargeParamCopy() {
+	max := f.config.LargeParamFields
+	fields := make(map[string]int)
+	for _, decl := range f.f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if st, ok := ts.Type.(*ast.StructType); ok && ts.TypeParams == nil {
+				fields[ts.Name.Name] = st.Fields.NumFields()
+			}
+		}
+	}
+	// numFields returns the number of fields of the struct passed by value
+	// as a value of type expr, or 0 if it isn't a struct.
+	numFields := func(expr ast.Expr) int {
+		if typ := f.typeOf(expr); typ != nil {
+			if st, ok := typ.Underlying().(*types.Struct); ok {
+				return st.NumFields()
+			}
+			return 0
+		}
+		if id, ok := expr.(*ast.Ident); ok {
+			return fields[id.Name]
+		}
+		return 0
+	}
+	checkFields := func(fl *ast.FieldList, what string) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			n := numFields(field.Type)
+			if n <= max {
+				continue
+			}
+			name := f.render(field.Type)
+			for _, id := range field.Names {
+				f.errorf(id, 0.5, category("performance"), "%s %s copies struct %s with %d fields on each call; consider passing *%s", what, id.Name, name, n, name)
+			}
+			if len(field.Names) == 0 {
+				f.errorf(field, 0.5, category("performance"), "%s copies struct %s with %d fields on each call; consider passing *%s", what, name, n, name)
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			checkFields(v.Recv, "receiver")
+			checkFields(v.Type.Params, "parameter")
+		case *ast.FuncLit:
+			checkFields(v.Type.Params, "parameter")
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))
+}
+
+// lintInlineConstraint examines the constraints of type parameters.
+// It complains about inline union constraints, such as ~int | ~string,
+// that are repeated in the file and could be a named interface instead.
+func (f *file) lintInlineConstraint() {
+	seen := f.constraints
+	f.visit(func(n ast.Node) bool {
+		var tparams *ast.FieldList
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			tparams = v.Type.TypeParams
+		case *ast.TypeSpec:
+			tparams = v.TypeParams
+		}
+		if tparams == nil {
+			return false
+		}
+		for _, field := range tparams.List {
+			if !isUnionConstraint(field.Type) {
+				continue
+			}
+			text := f.render(field.Type)
+			first, ok := seen[text]
+			if !ok {
+				seen[text] = field.Type.Pos()
+				continue
+			}
+			f.errorf(field.Type, 0.2, category("generics"), "constraint %s is repeated from line %d; consider a named interface", text, f.fset.Position(first).Line)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil), (*ast.TypeSpec)(nil))
+}
+
+// isUnionConstraint reports whether expr is a union or approximation
+// constraint like ~int | ~string, or an interface literal with one.
+func isUnionConstraint(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		return e.Op == token.OR
+	case *ast.UnaryExpr:
+		return e.Op == token.TILDE
+	case *ast.InterfaceType:
+		for _, m := range e.Methods.List {
+			if len(m.Names) == 0 && isUnionConstraint(m.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lintBuiltinPrint examines calls to the builtin print and println functions.
+// They write to standard error and are meant for debugging the runtime,
+// so it complains that fmt or log should be used instead.
+func (f *file) lintBuiltinPrint() {
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		id, ok := ce.Fun.(*ast.Ident)
+		if !ok || id.Name != "print" && id.Name != "println" || id.Obj != nil {
+			return true
+		}
+		if f.pkg.typesInfo != nil {
+			// It may be declared in another file of the package.
+			if _, ok := f.pkg.typesInfo.Uses[id].(*types.Builtin); !ok {
+				return true
+			}
+		}
+		f.errorf(ce, 0.8, category("output"), "call to builtin %s; use fmt or log instead", id.Name)
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// lintGlobalVars examines package-level variables.
+// It complains about exported ones initialized with a map or slice literal:
+// any importer can modify such shared state, so a function returning
+// a fresh copy is usually better.
+func (f *file) lintGlobalVars() {
+	f.visit(func(n ast.Node) bool {
+		gd, ok := n.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			// Variables in function bodies aren't globals.
+			return false
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, id := range vs.Names {
+				if i >= len(vs.Values) || !id.IsExported() {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+				var kind string
+				switch t := lit.Type.(type) {
+				case *ast.MapType:
+					kind = "map"
+				case *ast.ArrayType:
+					if t.Len != nil {
+						continue // arrays are copied on assignment
+					}
+					kind = "slice"
+				default:
+					continue
+				}
+				f.errorf(id, 0.4, category("globals"), "exported global %s is a mutable %s that any importer can modify; consider a function returning a copy", id.Name, kind)
+			}
+		}
+		return false
+	}, (*ast.FuncDecl)(nil), (*ast.GenDecl)(nil))
+}
+
+// lintEmptyErrorHandler examines if statements that check an error.
+// It complains if their block is empty or only has comments, which swallows
+// the error, unless a //nolint comment in it or after it marks that as intended.
+func (f *file) lintEmptyErrorHandler() {
+	f.visit(func(n ast.Node) bool {
+		ifs := n.(*ast.IfStmt)
+		if len(ifs.Body.List) > 0 {
+			return true
+		}
+		cond, ok := ifs.Cond.(*ast.BinaryExpr)
+		if !ok || cond.Op != token.NEQ {
+			return true
+		}
+		x := cond.X
+		if isIdent(x, "nil") {
+			x = cond.Y
+		} else if !isIdent(cond.Y, "nil") {
+			return true
+		}
+		if !f.isError(x) {
+			return true
+		}
+		rbrace := f.fset.Position(ifs.Body.Rbrace).Line
+		for _, cg := range f.commentsAfter(ifs.Pos()) {
+			if cg.Pos() > ifs.Body.Rbrace && f.fset.Position(cg.Pos()).Line != rbrace {
+				break
+			}
+			for _, c := range cg.List {
+				if strings.HasPrefix(c.Text, "//nolint") {
+					return true
+				}
+			}
+		}
+		f.errorf(ifs, 0.9, category("errors"), "empty block for %s swallows the error; handle it, or add a //nolint comment if that is intended", f.lazyRender(cond))
+		return true
+	}, (*ast.IfStmt)(nil))
+}
+
+// isError reports whether expr is an error. Without type information
+// it only recognizes variables named err or ending in Err.
+func (f *file) isError(expr ast.Expr) bool {
+	if t := f.typeOf(expr); t != nil {
+		return types.Identical(t, errorType)
+	}
+	id, ok := expr.(*ast.Ident)
+	return ok && (id.Name == "err" || strings.HasSuffix(id.Name, "Err"))
+}
+
+// lintSprintfKey examines loops.
+// It complains about map indexes built with fmt.Sprintf in them,
+// since formatting a key on every iteration is slow.
+func (f *file) lintSprintfKey() {
+	fmtName := f.importName("fmt")
+	if fmtName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch v := n.(type) {
+		case *ast.RangeStmt:
+			body = v.Body
+		case *ast.ForStmt:
+			body = v.Body
+		}
+		ast.Inspect(body, func(n ast.Node) bool {
+			ie, ok := n.(*ast.IndexExpr)
+			if !ok {
+				return true
+			}
+			// Only maps can be indexed by a string.
+			if ce, ok := ie.Index.(*ast.CallExpr); ok && isPkgDot(ce.Fun, fmtName, "Sprintf") {
+				f.errorf(ce, 0.3, category("performance"), "map key built with %s.Sprintf in a loop; consider a struct key or building it with strconv", fmtName)
+			}
+			return true
+		})
+		// Nested loops are covered by the inspection above.
+		return false
+	}, (*ast.RangeStmt)(nil), (*ast.ForStmt)(nil))
+}
+
+// literalTypes maps the kinds of basic literals to the names of their default types.
+var literalTypes = map[token.Token]string{
+	token.INT:    "int",
+	token.FLOAT:  "float64",
+	token.IMAG:   "complex128",
+	token.CHAR:   "rune",
+	token.STRING: "string",
+}
+
+// lintRedundantConversion examines type conversions.
+// It complains about those whose argument already has the type converted to.
+// Without type information only conversions of a literal to its default type,
+// like int(5) or string("a"), are recognized.
+func (f *file) lintRedundantConversion() {
+	aliases := map[string]string{"rune": "int32", "byte": "uint8"}
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		if len(ce.Args) != 1 || ce.Ellipsis.IsValid() {
+			return true
+		}
+		arg := ce.Args[0]
+		conf := 0.5
+		if lit, ok := arg.(*ast.BasicLit); ok {
+			id, ok := ce.Fun.(*ast.Ident)
+			if !ok || id.Obj != nil || builtinKinds[id.Name] != "type" {
+				return true
+			}
+			if want, name := literalTypes[lit.Kind], id.Name; want != name && aliases[want] != name && want != aliases[name] {
+				return true
+			}
+			if f.pkg.typesInfo != nil {
+				if _, ok := f.pkg.typesInfo.Uses[id].(*types.TypeName); !ok {
+					return true // it may be declared in another file
+				}
+				conf = 0.8
+			}
+		} else {
+			if f.pkg.typesInfo == nil {
+				return true
+			}
+			tv, ok := f.pkg.typesInfo.Types[ce.Fun]
+			if !ok || !tv.IsType() {
+				return true
+			}
+			// The conversion of a constant gives it its type.
+			if at, ok := f.pkg.typesInfo.Types[arg]; !ok || at.Value != nil || !types.Identical(at.Type, tv.Type) {
+				return true
+			}
+			conf = 0.8
+		}
+		fix := func() *SuggestedFix {
+			return f.fix(ce.Pos(), ce.End(), f.render(arg))
+		}
+		f.errorf(ce, conf, category("redundant"), fixTitle("Remove redundant conversion"), fix, "redundant conversion of %s to %s; it already has that type", f.lazyRender(arg), f.lazyRender(ce.Fun))
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// lintDoubleMapLookup examines if statements.
+// It complains about map index expressions in their condition that are
+// repeated in their block, which looks the key up twice; the value can be
+// looked up once with v, ok := m[k] instead.
+// Without type information slice and map indexes look the same, so any
+// index expression is considered.
+func (f *file) lintDoubleMapLookup() {
+	f.visit(func(n ast.Node) bool {
+		ifs := n.(*ast.IfStmt)
+		guards := make(map[string]*ast.IndexExpr)
+		ast.Inspect(ifs.Cond, func(n ast.Node) bool {
+			if ie, ok := n.(*ast.IndexExpr); ok && f.isMapIndex(ie) {
+				guards[f.render(ie)] = ie
+			}
+			return true
+		})
+		if len(guards) == 0 {
+			return true
+		}
+		stores := make(map[*ast.IndexExpr]bool)
+		ast.Inspect(ifs.Body, func(n ast.Node) bool {
+			switch v := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range v.Lhs {
+					if ie, ok := lhs.(*ast.IndexExpr); ok {
+						stores[ie] = true
+					}
+				}
+			case *ast.IncDecStmt:
+				if ie, ok := v.X.(*ast.IndexExpr); ok {
+					stores[ie] = true
+				}
+			case *ast.IndexExpr:
+				if stores[v] {
+					return true
+				}
+				text := f.render(v)
+				guard, ok := guards[text]
+				if !ok {
+					return true
+				}
+				delete(guards, text) // only flag the first use
+				f.errorf(v, 0.4, category("performance"), "%s is looked up again after the check at line %d; consider v, ok := %s", text, f.fset.Position(guard.Pos()).Line, text)
+			}
+			return true
+		})
+		return true
+	}, (*ast.IfStmt)(nil))
+}
+
+// isMapIndex reports whether ie may index a map.
+func (f *file) isMapIndex(ie *ast.IndexExpr) bool {
+	t := f.typeOf(ie.X)
+	if t == nil {
+		return true
+	}
+	_, ok := t.Underlying().(*types.Map)
+	return ok
+}
+
+// lintConstIndexRange examines variables declared with a slice literal.
+// It complains about constant indexes and slice bounds out of the range of
+// the literal, which are sure to panic. Only the statements that follow the
+// declaration in the same block are examined, up to any that may change
+// the variable.
+func (f *file) lintConstIndexRange() {
+	check := func(list []ast.Stmt) {
+		for i, stmt := range list {
+			id, rhs := definedVar(stmt)
+			if id == nil || id.Obj == nil {
+				continue
+			}
+			lit, ok := rhs.(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			if at, ok := lit.Type.(*ast.ArrayType); !ok || at.Len != nil {
+				continue // the compiler checks constant array indexes
+			}
+			n := literalLen(lit)
+			if n < 0 {
+				continue
+			}
+			for _, next := range list[i+1:] {
+				if mayReassign(next, id.Obj) {
+					break
+				}
+				ast.Inspect(next, func(node ast.Node) bool {
+					switch v := node.(type) {
+					case *ast.IndexExpr:
+						if x, ok := v.X.(*ast.Ident); ok && x.Obj == id.Obj {
+							if i, ok := intLit(v.Index); ok && i >= n {
+								f.errorf(v.Index, 0.6, category("suspicious"), "index %d out of range for %s, a slice literal of length %d", i, id.Name, n)
+							}
+						}
+					case *ast.SliceExpr:
+						if x, ok := v.X.(*ast.Ident); ok && x.Obj == id.Obj {
+							for _, bound := range []ast.Expr{v.Low, v.High, v.Max} {
+								if i, ok := intLit(bound); ok && i > n {
+									f.errorf(bound, 0.6, category("suspicious"), "slice bound %d out of range for %s, a slice literal of length %d", i, id.Name, n)
+									break
+								}
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.BlockStmt:
+			check(v.List)
+		case *ast.CaseClause:
+			check(v.Body)
+		case *ast.CommClause:
+			check(v.Body)
+		}
+		return true
+	}, (*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil))
+}
+
+// literalLen returns the length of the slice or array literal lit,
+// or -1 if it has an index that isn't an integer literal.
+func literalLen(lit *ast.CompositeLit) int64 {
+	var n, next int64
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			i, ok := intLit(kv.Key)
+			if !ok {
+				return -1
+			}
+			next = i
+		}
+		next++
+		if next > n {
+			n = next
+		}
+	}
+	return n
+}
+
+// intLit returns the value of expr if it is an integer literal.
+func intLit(expr ast.Expr) (int64, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(lit.Value, 0, 64)
+	return i, err == nil
+}
+
+// mayReassign reports whether n assigns to the variable obj or takes its address.
+func mayReassign(n ast.Node, obj *ast.Object) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range v.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && id.Obj == obj {
+					found = true
+				}
+			}
+		case *ast.UnaryExpr:
+			if id, ok := v.X.(*ast.Ident); ok && v.Op == token.AND && id.Obj == obj {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// lintMapRangeOrder examines loops ranging over a map.
+// Since the order of iteration over a map isn't specified, it complains if
+// their body appends to a slice declared outside the loop, unless the slice
+// is sorted later in the block, or writes output with fmt or a Write method.
+// It is only a hint: the order often doesn't matter.
+func (f *file) lintMapRangeOrder() {
+	fmtName, sortName, slicesName := f.importName("fmt"), f.importName("sort"), f.importName("slices")
+	sorted := func(list []ast.Stmt, obj *ast.Object) bool {
+		for _, stmt := range list {
+			found := false
+			ast.Inspect(stmt, func(n ast.Node) bool {
+				ce, ok := n.(*ast.CallExpr)
+				if !ok || found {
+					return !found
+				}
+				sel, ok := ce.Fun.(*ast.SelectorExpr)
+				if ok && (sortName != "" && isIdent(sel.X, sortName) || slicesName != "" && isIdent(sel.X, slicesName)) {
+					for _, arg := range ce.Args {
+						if findObjRef(arg, obj) != nil {
+							found = true
+						}
+					}
+				}
+				return !found
+			})
+			if found {
+				return true
+			}
+		}
+		return false
+	}
+	check := func(list []ast.Stmt) {
+		for i, stmt := range list {
+			rs, ok := stmt.(*ast.RangeStmt)
+			if !ok || !f.isMap(rs.X) {
+				continue
+			}
+			var problem string
+			ast.Inspect(rs.Body, func(n ast.Node) bool {
+				if problem != "" {
+					return false
+				}
+				switch v := n.(type) {
+				case *ast.AssignStmt:
+					if len(v.Lhs) != 1 || len(v.Rhs) != 1 {
+						return true
+					}
+					id, ok := v.Lhs[0].(*ast.Ident)
+					ce, isCall := v.Rhs[0].(*ast.CallExpr)
+					if !ok || !isCall || !isIdent(ce.Fun, "append") || id.Obj == nil {
+						return true
+					}
+					if decl, ok := id.Obj.Decl.(ast.Node); !ok || rs.Body.Pos() <= decl.Pos() && decl.Pos() < rs.Body.End() {
+						return true
+					}
+					if !sorted(list[i+1:], id.Obj) {
+						problem = "appends to " + id.Name
+					}
+				case *ast.CallExpr:
+					sel, ok := v.Fun.(*ast.SelectorExpr)
+					if !ok {
+						return true
+					}
+					switch name := sel.Sel.Name; {
+					case fmtName != "" && isIdent(sel.X, fmtName) && (strings.HasPrefix(name, "Print") || strings.HasPrefix(name, "Fprint")):
+						problem = "writes output with " + fmtName + "." + name
+					case name == "Write" || name == "WriteString":
+						problem = "writes output with " + name
+					}
+				}
+				return true
+			})
+			if problem != "" {
+				f.errorf(rs, 0.4, category("correctness"), "range over map %s, whose order is random, %s; sort the keys first if the order matters", f.lazyRender(rs.X), problem)
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.BlockStmt:
+			check(v.List)
+		case *ast.CaseClause:
+			check(v.Body)
+		case *ast.CommClause:
+			check(v.Body)
+		}
+		return true
+	}, (*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil))
+}
+
+// isMap reports whether expr is a map. Without type information only
+// the variables recognized by declaredType are.
+func (f *file) isMap(expr ast.Expr) bool {
+	if t := f.typeOf(expr); t != nil {
+		_, ok := t.Underlying().(*types.Map)
+		return ok
+	}
+	_, ok := declaredType(expr).(*ast.MapType)
+	return ok
+}
+
+// lintRedundantNamedConversion examines conversions of composite literals.
+// It complains about those to the type of the literal, like Foo(Foo{}) or
+// (*Foo)(&Foo{}), which can be written as just the literal.
+func (f *file) lintRedundantNamedConversion() {
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		if len(ce.Args) != 1 || ce.Ellipsis.IsValid() {
+			return true
+		}
+		arg := ce.Args[0]
+		typ := ce.Fun
+		for p, ok := typ.(*ast.ParenExpr); ok; p, ok = typ.(*ast.ParenExpr) {
+			typ = p.X
+		}
+		lit, ok := arg.(*ast.CompositeLit)
+		if u, isAddr := arg.(*ast.UnaryExpr); isAddr && u.Op == token.AND {
+			star, isStar := typ.(*ast.StarExpr)
+			if !isStar {
+				return true
+			}
+			typ = star.X
+			lit, ok = u.X.(*ast.CompositeLit)
+		}
+		if !ok || lit.Type == nil || f.render(typ) != f.render(lit.Type) {
+			return true
+		}
+		fix := func() *SuggestedFix {
+			return f.fix(ce.Pos(), ce.End(), f.render(arg))
+		}
+		f.errorf(ce, 0.5, category("simplify"), fixTitle("Remove redundant conversion"), fix, "conversion of a %s literal to its own type is redundant", f.lazyRender(lit.Type))
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// lintNestedAppend examines append calls.
+// It complains about those appending to the result of another append,
+// like append(append(s, a), b), which is clearer as one variadic append
+// or separate statements. The outermost call is reported with the depth.
+func (f *file) lintNestedAppend() {
+	inner := make(map[*ast.CallExpr]bool)
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		if inner[ce] || !isAppend(ce) {
+			return true
+		}
+		depth := 1
+		for call := ce; len(call.Args) > 0; depth++ {
+			next, ok := call.Args[0].(*ast.CallExpr)
+			if !ok || !isAppend(next) {
+				break
+			}
+			inner[next] = true
+			call = next
+		}
+		if depth > 1 {
+			f.errorf(ce, 0.2, category("style"), "append nested %d deep; consider a single variadic append or separate statements", depth)
+		}
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// isAppend reports whether ce is a call to the builtin append.
+func isAppend(ce *ast.CallExpr) bool {
+	id, ok := ce.Fun.(*ast.Ident)
+	return ok && id.Name == "append" && id.Obj == nil
+}
+
+// lintDeferCloseNamedErr examines functions with a named error result.
+// It complains about bare deferred Close calls in them, whose error is lost
+// although it could be returned through the named result.
+func (f *file) lintDeferCloseNamedErr() {
+	f.visit(func(n ast.Node) bool {
+		var ft *ast.FuncType
+		var body *ast.BlockStmt
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			ft, body = v.Type, v.Body
+		case *ast.FuncLit:
+			ft, body = v.Type, v.Body
+		}
+		if body == nil || ft.Results == nil {
+			return true
+		}
+		var errName string
+		for _, field := range ft.Results.List {
+			if !isIdent(field.Type, "error") {
+				continue
+			}
+			for _, name := range field.Names {
+				if !isBlank(name) {
+					errName = name.Name
+				}
+			}
+		}
+		if errName == "" {
+			return true
+		}
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch v := n.(type) {
+			case *ast.FuncLit:
+				return false // checked on its own
+			case *ast.DeferStmt:
+				sel, ok := v.Call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "Close" || len(v.Call.Args) != 0 {
+					return true
+				}
+				x := f.lazyRender(sel.X)
+				f.errorf(v, 0.3, category("errors"), "error of deferred %s.Close is lost; consider defer func() { if cerr := %s.Close(); cerr != nil && %s == nil { %s = cerr } }()", x, x, errName, errName)
+			}
+			return true
+		})
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))
+}
+
+// lintMagicNumbers examines numeric literals.
+// It complains about those other than 0, 1, 2, -1 and Config.AllowedMagicNumbers
+// that are not in a constant declaration or array length, suggesting
+// naming them. Test files are skipped, and so are literals multiplied by
+// a time unit, like 5 * time.Second, unless Config.MagicNumbersDurations is set.
+func (f *file) lintMagicNumbers() {
+	if f.isTest() {
+		return
+	}
+	timeName := f.importName("time")
+	check := func(lit *ast.BasicLit, neg bool) {
+		v := constant.MakeFromLiteral(lit.Value, lit.Kind, 0)
+		if neg {
+			v = constant.UnaryOp(token.SUB, v, 0)
+		}
+		for _, allowed := range f.pkg.magicNumbers {
+			if constant.Compare(v, token.EQL, allowed) {
+				return
+			}
+		}
+		text := lit.Value
+		if neg {
+			text = "-" + text
+		}
+		f.errorf(lit, 0.4, category("style"), "magic number %s; consider naming it with a constant", text)
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.GenDecl:
+			return v.Tok != token.CONST
+		case *ast.ArrayType:
+			return false
+		case *ast.BinaryExpr:
+			if v.Op != token.MUL || f.config.MagicNumbersDurations || timeName == "" {
+				return true
+			}
+			for _, x := range []ast.Expr{v.X, v.Y} {
+				if sel, ok := x.(*ast.SelectorExpr); ok && isIdent(sel.X, timeName) && timeUnits[sel.Sel.Name] {
+					return false
+				}
+			}
+		case *ast.UnaryExpr:
+			if lit, ok := v.X.(*ast.BasicLit); ok && v.Op == token.SUB && isNumber(lit) {
+				check(lit, true)
+				return false
+			}
+		case *ast.BasicLit:
+			if isNumber(v) {
+				check(v, false)
+			}
+		}
+		return true
+	}, (*ast.GenDecl)(nil), (*ast.ArrayType)(nil), (*ast.BinaryExpr)(nil), (*ast.UnaryExpr)(nil), (*ast.BasicLit)(nil))
+}
+
+// timeUnits are the duration constants of package time.
+var timeUnits = map[string]bool{
+	"Nanosecond":  true,
+	"Microsecond": true,
+	"Millisecond": true,
+	"Second":      true,
+	"Minute":      true,
+	"Hour":        true,
+}
+
+// isNumber reports whether lit is a numeric literal.
+func isNumber(lit *ast.BasicLit) bool {
+	return lit.Kind == token.INT || lit.Kind == token.FLOAT || lit.Kind == token.IMAG
+}
+
+// parseNumber parses s, an integer or floating-point literal with
+// an optional minus sign, like "-1" or "0.5".
+func parseNumber(s string) (constant.Value, bool) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	v := constant.MakeFromLiteral(s, token.INT, 0)
+	if v.Kind() == constant.Unknown {
+		v = constant.MakeFromLiteral(s, token.FLOAT, 0)
+	}
+	if v.Kind() == constant.Unknown {
+		return v, false
+	}
+	if neg {
+		v = constant.UnaryOp(token.SUB, v, 0)
+	}
+	return v, true
+}
+
+// lintInit examines init functions.
+// It complains about one with more than Config.MaxInitStatements top-level
+// statements, or that follows another in the same file, since such
+// initialization is hard to test; explicit initialization is better.
+// An init that only makes one call, usually to register something, is exempt.
+func (f *file) lintInit() {
+	isInit := func(decl ast.Decl) bool {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != "init" || fn.Body == nil {
+			return false
+		}
+		if len(fn.Body.List) == 1 {
+			if es, ok := fn.Body.List[0].(*ast.ExprStmt); ok {
+				if _, ok := es.X.(*ast.CallExpr); ok {
+					return false // registration only
+				}
+			}
+		}
+		return true
+	}
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if !isInit(fn) {
+			return false
+		}
+		for _, decl := range f.f.Decls {
+			if decl == fn {
+				break
+			}
+			if isInit(decl) {
+				f.errorf(fn, 0.5, category("init"), "more than one init function in file, the first at line %d; consider explicit initialization", f.fset.Position(decl.Pos()).Line)
+				return false
+			}
+		}
+		if max := f.config.MaxInitStatements; len(fn.Body.List) > max {
+			f.errorf(fn, 0.5, category("init"), "init function has %d statements, more than %d; consider explicit initialization", len(fn.Body.List), max)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintMissingExamples examines exported functions and types.
+// It complains about those without an example function in the test files
+// of the package, if it has at least Config.ExamplesMinSymbols of them,
+// so small packages aren't nagged. Examples in an external test package
+// are only seen by LintDir.
+func (f *file) lintMissingExamples() {
+	if f.isTest() || f.main || f.pkg.exported < f.config.ExamplesMinSymbols {
+		return
+	}
+	report := func(id *ast.Ident, kind string) {
+		if id.IsExported() && !f.pkg.examples[id.Name] {
+			f.errorf(id, 0.3, category("docs"), "exported %s %s has no example; consider adding Example%s", kind, id.Name, id.Name)
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			if v.Recv == nil {
+				report(v.Name, "func")
+			}
+		case *ast.GenDecl:
+			if v.Tok != token.TYPE {
+				return false
+			}
+			for _, spec := range v.Specs {
+				report(spec.(*ast.TypeSpec).Name, "type")
+			}
+		}
+		return false
+	}, (*ast.FuncDecl)(nil), (*ast.GenDecl)(nil))
+}
+
+// addExamples records the names of the identifiers that the example functions
+// of f are for, such as Foo for ExampleFoo, ExampleFoo_Bar and ExampleFoo_suffix.
+func addExamples(examples map[string]bool, f *ast.File) {
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Example") {
+			continue
+		}
+		name := strings.TrimPrefix(fn.Name.Name, "Example")
+		if i := strings.Index(name, "_"); i >= 0 {
+			name = name[:i]
+		}
+		examples[name] = true
+	}
+}
+
+// lintUselessWrap examines fmt.Errorf calls.
+// It complains about those that only format an error, like
+// fmt.Errorf("%w", err), which adds no context; the error should be
+// returned as it is, or given some context.
+func (f *file) lintUselessWrap() {
+	fmtName := f.importName("fmt")
+	if fmtName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		if !isPkgDot(ce.Fun, fmtName, "Errorf") || len(ce.Args) != 2 {
+			return true
+		}
+		lit, ok := ce.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		format, err := strconv.Unquote(lit.Value)
+		if err != nil || format != "%w" && format != "%v" && format != "%s" {
+			return true
+		}
+		arg := ce.Args[1]
+		if !f.isError(arg) {
+			return true
+		}
+		fix := func() *SuggestedFix {
+			return f.fix(ce.Pos(), ce.End(), f.render(arg))
+		}
+		f.errorf(ce, 0.8, category("errors"), fixTitle("Return error directly"), fix, "%s.Errorf(%s, %s) adds no context; return %s directly or add context", fmtName, lit.Value, f.lazyRender(arg), f.lazyRender(arg))
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// lintSliceEquality examines == and != comparisons.
+// It complains about those of two slices or two maps, which can only be
+// compared to nil; their elements can be compared with slices.Equal,
+// maps.Equal or reflect.DeepEqual.
+func (f *file) lintSliceEquality() {
+	f.visit(func(n ast.Node) bool {
+		be := n.(*ast.BinaryExpr)
+		if be.Op != token.EQL && be.Op != token.NEQ {
+			return true
+		}
+		if isIdent(be.X, "nil") || isIdent(be.Y, "nil") {
+			return true
+		}
+		kind := f.sliceOrMap(be.X)
+		if kind == "" || f.sliceOrMap(be.Y) != kind {
+			return true
+		}
+		eq := "slices.Equal"
+		if kind == "map" {
+			eq = "maps.Equal"
+		}
+		f.errorf(be, 0.4, category("suspicious"), "%ss can only be compared to nil; use %s or reflect.DeepEqual to compare %s and %s", kind, eq, f.lazyRender(be.X), f.lazyRender(be.Y))
+		return true
+	}, (*ast.BinaryExpr)(nil))
+}
+
+// sliceOrMap returns "slice" or "map" if expr is known to be a slice or map,
+// such as a composite literal or a variable declared with such a type,
+// or "" otherwise.
+func (f *file) sliceOrMap(expr ast.Expr) string {
+	if t := f.typeOf(expr); t != nil {
+		switch t.Underlying().(type) {
+		case *types.Slice:
+			return "slice"
+		case *types.Map:
+			return "map"
+		}
+		return ""
+	}
+	typ := declaredType(expr)
+	if lit, ok := expr.(*ast.CompositeLit); ok {
+		typ = lit.Type
+	}
+	switch t := typ.(type) {
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "slice"
+		}
+	case *ast.MapType:
+		return "map"
+	}
+	return ""
+}
+
+// lintNoCopy examines function signatures and range statements.
+// It complains about values of the types in Config.NoCopyTypes, such as
+// sync.WaitGroup, and of the structs of the file that contain them,
+// that are copied by being passed, returned or ranged over by value.
+func (f *file) lintNoCopy() {
+	noCopy := f.noCopyTypes()
+	if len(noCopy) == 0 {
+		return
+	}
+	describe := func(name string) string {
+		if inner := noCopy[name]; inner != "" {
+			return fmt.Sprintf("%s, which contains a %s", name, inner)
+		}
+		return name
+	}
+	checkFields := func(fl *ast.FieldList, what string) {
+		if fl == nil {
+			return
+		}
+		for _, field := range fl.List {
+			name := noCopyName(field.Type)
+			if _, ok := noCopy[name]; !ok {
+				continue
+			}
+			for _, id := range field.Names {
+				f.errorf(id, 0.6, category("concurrency"), "%s %s copies a %s, which must not be copied; use *%s", what, id.Name, describe(name), name)
+			}
+			if len(field.Names) == 0 {
+				f.errorf(field, 0.6, category("concurrency"), "%s copies a %s, which must not be copied; use *%s", what, describe(name), name)
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			checkFields(v.Recv, "receiver")
+			checkFields(v.Type.Params, "parameter")
+			checkFields(v.Type.Results, "result")
+		case *ast.FuncLit:
+			checkFields(v.Type.Params, "parameter")
+			checkFields(v.Type.Results, "result")
+		case *ast.RangeStmt:
+			id, ok := v.Value.(*ast.Ident)
+			if !ok || isBlank(id) {
+				return true
+			}
+			name := f.rangeElemName(v)
+			if _, ok := noCopy[name]; ok {
+				f.errorf(id, 0.6, category("concurrency"), "range variable %s copies a %s, which must not be copied; range over the indexes instead", id.Name, describe(name))
+			}
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil), (*ast.RangeStmt)(nil))
+}
+
+// noCopyTypes returns the names, as written in the file, of the types in
+// Config.NoCopyTypes the file imports, mapped to "", and of the struct types
+// of the file containing a value of one of them, mapped to the name of the
+// type they contain.
+func (f *file) noCopyTypes() map[string]string {
+	noCopy := make(map[string]string)
+	for _, typ := range f.config.NoCopyTypes {
+		i := strings.LastIndex(typ, ".")
+		if i < 0 {
+			continue
+		}
+		if name := f.importName(typ[:i]); name != "" {
+			noCopy[name+"."+typ[i+1:]] = ""
+		}
+	}
+	if len(noCopy) == 0 {
+		return nil
+	}
+	// A struct can contain another struct of the file declared after it,
+	// so look until no more are found.
+	for found := true; found; {
+		found = false
+		for _, decl := range f.f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if _, seen := noCopy[ts.Name.Name]; !ok || seen {
+					continue
+				}
+				for _, field := range st.Fields.List {
+					name := noCopyName(field.Type)
+					if inner, ok := noCopy[name]; ok {
+						if inner == "" {
+							inner = name
+						}
+						noCopy[ts.Name.Name] = inner
+						found = true
+						break
+					}
+				}
+			}
+		}
+	}
+	return noCopy
+}
+
+// noCopyName returns the name of typ if it is a type name like T or pkg.T,
+// or "" otherwise.
+func noCopyName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok {
+			return pkg.Name + "." + t.Sel.Name
+		}
+	}
+	return ""
+}
+
+// rangeElemName returns the name, as written in the file, of the type of
+// the value variable of rs, or "" if it is not known. Without type
+// information only ranges over variables declared with a slice, array or
+// map type are known.
+func (f *file) rangeElemName(rs *ast.RangeStmt) string {
+	if t := f.typeOf(rs.Value); t != nil {
+		named, ok := t.(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return ""
+		}
+		if named.Obj().Pkg() == f.pkg.typesPkg {
+			return named.Obj().Name()
+		}
+		if name := f.importName(named.Obj().Pkg().Path()); name != "" {
+			return name + "." + named.Obj().Name()
+		}
+		return ""
+	}
+	switch t := declaredType(rs.X).(type) {
+	case *ast.ArrayType:
+		return noCopyName(t.Elt)
+	case *ast.MapType:
+		return noCopyName(t.Value)
+	}
+	return ""
+}
+
+// lintLenNegative examines comparisons of len and cap calls with integer literals.
+// It complains about those that are always true or always false since
+// lengths and capacities are never negative, like len(s) < 0 or len(s) >= 0.
+func (f *file) lintLenNegative() {
+	f.visit(func(n ast.Node) bool {
+		be := n.(*ast.BinaryExpr)
+		call, x, op := lenCall(be.X), be.Y, be.Op
+		if call == nil {
+			// Put the call on the left: 0 > len(s) is len(s) < 0.
+			call, x, op = lenCall(be.Y), be.X, flipComparison(be.Op)
+		}
+		if call == nil {
+			return true
+		}
+		neg := false
+		if u, ok := x.(*ast.UnaryExpr); ok && u.Op == token.SUB {
+			neg, x = true, u.X
+		}
+		c, ok := intLit(x)
+		if !ok {
+			return true
+		}
+		if neg {
+			c = -c
+		}
+		var always bool
+		switch {
+		case op == token.LSS && c <= 0, op == token.LEQ && c < 0, op == token.EQL && c < 0:
+			always = false
+		case op == token.GEQ && c <= 0, op == token.GTR && c < 0, op == token.NEQ && c < 0:
+			always = true
+		default:
+			return true
+		}
+		f.errorf(be, 0.9, category("correctness"), "%s is always %t; the result of %s is never negative", f.lazyRender(be), always, call.Fun.(*ast.Ident).Name)
+		return true
+	}, (*ast.BinaryExpr)(nil))
+}
+
+// lenCall returns expr if it is a call of the builtin len or cap, or nil otherwise.
+func lenCall(expr ast.Expr) *ast.CallExpr {
+	ce, ok := expr.(*ast.CallExpr)
+	if !ok || len(ce.Args) != 1 {
+		return nil
+	}
+	id, ok := ce.Fun.(*ast.Ident)
+	if !ok || id.Obj != nil || id.Name != "len" && id.Name != "cap" {
+		return nil
+	}
+	return ce
+}
+
+// flipComparison returns the comparison operator to use when swapping
+// the operands of op, like > for <.
+func flipComparison(op token.Token) token.Token {
+	switch op {
+	case token.LSS:
+		return token.GTR
+	case token.GTR:
+		return token.LSS
+	case token.LEQ:
+		return token.GEQ
+	case token.GEQ:
+		return token.LEQ
+	}
+	return op
+}
+
+// lintEmbeddedMutex examines exported struct types.
+// It complains about embedded sync.Mutex and sync.RWMutex fields, which
+// make Lock and Unlock part of the API of the type.
+func (f *file) lintEmbeddedMutex() {
+	syncName := f.importName("sync")
+	if syncName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ts := n.(*ast.TypeSpec)
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok || !ast.IsExported(ts.Name.Name) {
+			return false
+		}
+		for _, field := range st.Fields.List {
+			if len(field.Names) > 0 {
+				continue
+			}
+			typ := field.Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if isPkgDot(typ, syncName, "Mutex") || isPkgDot(typ, syncName, "RWMutex") {
+				f.errorf(field, 0.5, category("concurrency"), "exported type %s embeds %s, which exports its locking methods; use an unexported field like mu %s instead", ts.Name.Name, f.lazyRender(field.Type), f.lazyRender(field.Type))
+			}
+		}
+		return false
+	}, (*ast.TypeSpec)(nil))
+}
+
+// lintMapStructAppend examines appends to slice fields of map values.
+// When the values of a map are structs, m[k].Items = append(m[k].Items, x)
+// doesn't compile, and the result of append(m[k].Items, x) must be stored
+// back with m[k] = v. It complains about such appends when the rest of the
+// block never assigns to an element of the map.
+func (f *file) lintMapStructAppend() {
+	check := func(list []ast.Stmt) {
+		for i, stmt := range list {
+			var call *ast.CallExpr
+			if as, ok := stmt.(*ast.AssignStmt); ok && len(as.Rhs) == 1 {
+				call, _ = as.Rhs[0].(*ast.CallExpr)
+			}
+			if call == nil || !isAppend(call) || len(call.Args) == 0 {
+				continue
+			}
+			sel, ok := call.Args[0].(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			ie, ok := sel.X.(*ast.IndexExpr)
+			if !ok || !f.isStructMap(ie.X) {
+				continue
+			}
+			mapText := f.render(ie.X)
+			stored := false
+			for _, later := range list[i+1:] {
+				ast.Inspect(later, func(n ast.Node) bool {
+					if as, ok := n.(*ast.AssignStmt); ok {
+						for _, lhs := range as.Lhs {
+							if lie, ok := lhs.(*ast.IndexExpr); ok && f.render(lie.X) == mapText {
+								stored = true
+							}
+						}
+					}
+					return !stored
+				})
+			}
+			if !stored {
+				f.errorf(call, 0.4, category("maps"), "append to field %s of the value of map %s at key %s has no effect on the map unless the value is stored back with %s[%s] = v", sel.Sel.Name, mapText, f.lazyRender(ie.Index), mapText, f.lazyRender(ie.Index))
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.BlockStmt:
+			check(v.List)
+		case *ast.CaseClause:
+			check(v.Body)
+		case *ast.CommClause:
+			check(v.Body)
+		}
+		return true
+	}, (*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil))
+}
+
+// isStructMap reports whether expr is known to be a map whose values are
+// structs, not pointers to them. Without type information only maps
+// declared with a struct type of the file as value type are known.
+func (f *file) isStructMap(expr ast.Expr) bool {
+	if t := f.typeOf(expr); t != nil {
+		m, ok := t.Underlying().(*types.Map)
+		if !ok {
+			return false
+		}
+		_, ok = m.Elem().Underlying().(*types.Struct)
+		return ok
+	}
+	mt, ok := declaredType(expr).(*ast.MapType)
+	if !ok {
+		return false
+	}
+	switch v := mt.Value.(type) {
+	case *ast.StructType:
+		return true
+	case *ast.Ident:
+		if v.Obj == nil || v.Obj.Kind != ast.Typ {
+			return false
+		}
+		ts, ok := v.Obj.Decl.(*ast.TypeSpec)
+		if !ok {
+			return false
+		}
+		_, ok = ts.Type.(*ast.StructType)
+		return ok
+	}
+	return false
+}
+
+// predicatePrefixes are the prefixes of names of functions expected to return a bool.
+var predicatePrefixes = []string{"Is", "Has", "Can", "Should"}
+
+// lintPredicateNaming examines function and method names.
+// It complains about those that read as a question, like IsValid or hasKey,
+// but don't return a bool, such as func IsValid() error.
+func (f *file) lintPredicateNaming() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		prefix := predicatePrefix(fn.Name.Name)
+		if prefix == "" {
+			return false
+		}
+		if fn.Type.Results != nil {
+			for _, field := range fn.Type.Results.List {
+				if isIdent(field.Type, "bool") {
+					return false
+				}
+				if t := f.typeOf(field.Type); t != nil && types.Identical(t.Underlying(), types.Typ[types.Bool]) {
+					return false
+				}
+			}
+		}
+		thing := "func"
+		if fn.Recv != nil {
+			thing = "method"
+		}
+		f.errorf(fn.Name, 0.5, category("naming"), "%s %s reads as a question (%s...) but doesn't return a bool; rename it or return a bool", thing, fn.Name.Name, prefix)
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// predicatePrefix returns the prefix of predicatePrefixes name starts with,
+// in either case, if it is followed by an upper case letter or nothing,
+// or "" otherwise.
+func predicatePrefix(name string) string {
+	for _, prefix := range predicatePrefixes {
+		lower := strings.ToLower(prefix[:1]) + prefix[1:]
+		for _, p := range []string{prefix, lower} {
+			if !strings.HasPrefix(name, p) {
+				continue
+			}
+			rest := name[len(p):]
+			if r, _ := utf8.DecodeRuneInString(rest); rest == "" || unicode.IsUpper(r) {
+				return p
+			}
+		}
+	}
+	return ""
+}
+
+// lintRawString examines interpreted string literals.
+// It complains about those with at least Config.RawStringMinEscapes escaped
+// backslashes and quotes and no other escapes, like "C:\\Users\\foo" or
+// regular expressions, which are easier to read as raw strings.
+func (f *file) lintRawString() {
+	min := f.config.RawStringMinEscapes
+	f.visit(func(n ast.Node) bool {
+		lit := n.(*ast.BasicLit)
+		if lit.Kind != token.STRING || !strings.HasPrefix(lit.Value, `"`) {
+			return true
+		}
+		escapes := 0
+		v := lit.Value[1 : len(lit.Value)-1]
+		for i := 0; i < len(v); i++ {
+			if v[i] != '\\' {
+				continue
+			}
+			i++
+			if v[i] != '\\' && v[i] != '"' {
+				// A raw string can't hold the character.
+				return true
+			}
+			escapes++
+		}
+		if escapes < min {
+			return true
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil || strings.ContainsAny(s, "`\r") {
+			return true
+		}
+		raw := "`" + s + "`"
+		f.errorf(lit, 0.5, category("formatting"), fixTitle("Use raw string"), f.fix(lit.Pos(), lit.End(), raw), "string literal with %d escaped backslashes or quotes would be more readable as the raw string %s", escapes, raw)
+		return true
+	}, (*ast.BasicLit)(nil))
+}
+
+// printfVerbs returns the verbs of format, a fmt.Printf format, in the order
+// of the arguments they use. A * width or precision uses an argument too and
+// is returned as '*'. It returns false if format uses explicit argument
+// indexes, like %[1]d, or is malformed.
+func printfVerbs(format string) ([]rune, bool) {
+	var verbs []rune
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		// Skip flags, width and precision.
+		for ; i < len(format) && strings.IndexByte("+-# 0.123456789*[", format[i]) >= 0; i++ {
+			switch format[i] {
+			case '*':
+				verbs = append(verbs, '*')
+			case '[':
+				return nil, false
+			}
+		}
+		if i == len(format) {
+			return nil, false
+		}
+		verb, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		if verb != '%' {
+			verbs = append(verbs, verb)
+		}
+	}
+	return verbs, true
+}
+
+// lintWrapNonError examines fmt.Errorf calls with a %w verb.
+// It complains about the arguments of %w that are not errors, like
+// literals or variables of a basic type, which fmt can't wrap.
+func (f *file) lintWrapNonError() {
+	fmtName := f.importName("fmt")
+	if fmtName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		if !isPkgDot(ce.Fun, fmtName, "Errorf") || len(ce.Args) < 2 || ce.Ellipsis.IsValid() {
+			return true
+		}
+		lit, ok := ce.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		format, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		verbs, ok := printfVerbs(format)
+		if !ok {
+			return true
+		}
+		for i, verb := range verbs {
+			if verb != 'w' || i+1 >= len(ce.Args) {
+				continue
+			}
+			arg := ce.Args[i+1]
+			if typ := f.nonErrorType(arg); typ != "" {
+				f.errorf(arg, 0.5, category("errors"), "%%w in %s.Errorf needs an error, but %s is of type %s; use %%v instead", fmtName, f.lazyRender(arg), typ)
+			}
+		}
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// nonErrorType returns the type of expr if it is known not to be an error,
+// or "" otherwise. Without type information only literals and variables
+// declared with a basic type or a literal are known.
+func (f *file) nonErrorType(expr ast.Expr) string {
+	if t := f.typeOf(expr); t != nil {
+		if types.Implements(t, errorInterface) || types.IsInterface(t) {
+			return ""
+		}
+		return t.String()
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		if lit.Kind == token.INT {
+			return "int"
+		}
+		return basicLitKindTypes[lit.Kind]
+	}
+	switch typ := declaredType(expr).(type) {
+	case *ast.Ident:
+		if obj, ok := types.Universe.Lookup(typ.Name).(*types.TypeName); ok && typ.Obj == nil && !types.IsInterface(obj.Type()) {
+			return typ.Name
+		}
+	case *ast.ArrayType, *ast.MapType:
+		return f.render(typ)
+	}
+	return ""
+}
+
+// lintUnusedContext examines functions with a context.Context parameter.
+// It complains about those that never use it, since callers would expect
+// the function to stop when the context is canceled. Parameters named _ or
+// not named are deliberately unused. Methods named like a method of an
+// interface declared in the package are skipped, since the interface may
+// require the parameter; interfaces of other packages aren't known.
+func (f *file) lintUnusedContext() {
+	ctxName := f.importName("context")
+	if ctxName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body == nil || fn.Recv != nil && f.pkg.interfaceMethods[fn.Name.Name] {
+			return false
+		}
+		for _, field := range fn.Type.Params.List {
+			if !isPkgDot(field.Type, ctxName, "Context") {
+				continue
+			}
+			for _, name := range field.Names {
+				if !isBlank(name) && findObjRef(fn.Body, name.Obj) == nil {
+					f.errorf(name, 0.6, category("context"), "context parameter %s is not used in %s; pass it on or name it _ if it is not needed", name.Name, fn.Name.Name)
+				}
+			}
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintRepeatedCleanup examines functions with several return statements.
+// It complains about cleanup calls without arguments, like conn.Close()
+// or mu.Unlock(), made right before two or more of the returns, which
+// could be deferred once instead.
+func (f *file) lintRepeatedCleanup() {
+	check := func(body *ast.BlockStmt) {
+		if body == nil {
+			return
+		}
+		first := make(map[string]*ast.CallExpr)
+		count := make(map[string]int)
+		deferred := make(map[string]bool)
+		var order []string
+		checkList := func(list []ast.Stmt) {
+			for i := 1; i < len(list); i++ {
+				if _, ok := list[i].(*ast.ReturnStmt); !ok {
+					continue
+				}
+				es, ok := list[i-1].(*ast.ExprStmt)
+				if !ok {
+					continue
+				}
+				call, ok := es.X.(*ast.CallExpr)
+				if !ok || len(call.Args) != 0 {
+					continue
+				}
+				text := f.render(call)
+				if count[text] == 0 {
+					order = append(order, text)
+				}
+				if count[text] == 0 || call.Pos() < first[text].Pos() {
+					first[text] = call
+				}
+				count[text]++
+			}
+		}
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch v := n.(type) {
+			case *ast.FuncLit:
+				// Its returns are checked on their own.
+				return false
+			case *ast.DeferStmt:
+				deferred[f.render(v.Call)] = true
+			case *ast.BlockStmt:
+				checkList(v.List)
+			case *ast.CaseClause:
+				checkList(v.Body)
+			case *ast.CommClause:
+				checkList(v.Body)
+			}
+			return true
+		})
+		for _, text := range order {
+			if count[text] >= 2 && !deferred[text] {
+				f.errorf(first[text], 0.3, category("style"), "%s is called before %d return statements; consider defer %s instead", text, count[text], text)
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.FuncDecl:
+			check(v.Body)
+		case *ast.FuncLit:
+			check(v.Body)
+		}
+		return true
+	}, (*ast.FuncDecl)(nil), (*ast.FuncLit)(nil))
+}
+
+// loopExitComment matches comments that say a loop should end.
+var loopExitComment = regexp.MustCompile(`(?i)\b(loop|exit|stop|done|finish)`)
+
+// lintBreakInSwitch examines switch and select statements in loops.
+// It complains about cases ending in an unlabeled break, which only exits
+// the switch or select, when it looks meant to exit the loop: the break is
+// the only statement of the case or follows a comment about ending the loop.
+func (f *file) lintBreakInSwitch() {
+	check := func(kind string, clauses []ast.Stmt) {
+		for _, clause := range clauses {
+			var colon token.Pos
+			var body []ast.Stmt
+			switch c := clause.(type) {
+			case *ast.CaseClause:
+				colon, body = c.Colon, c.Body
+			case *ast.CommClause:
+				colon, body = c.Colon, c.Body
+			}
+			if len(body) == 0 {
+				continue
+			}
+			br, ok := body[len(body)-1].(*ast.BranchStmt)
+			if !ok || br.Tok != token.BREAK || br.Label != nil {
+				continue
+			}
+			intended := len(body) == 1
+			from := colon
+			if len(body) > 1 {
+				from = body[len(body)-2].End()
+			}
+			for _, cg := range f.commentsAfter(from + 1) {
+				if cg.End() >= br.Pos() {
+					break
+				}
+				if loopExitComment.MatchString(cg.Text()) {
+					intended = true
+					break
+				}
+			}
+			if intended {
+				f.errorf(br, 0.2, category("control-flow"), "break only exits the %s, not the enclosing loop; use a labeled break to end the loop", kind)
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch v := n.(type) {
+		case *ast.ForStmt:
+			body = v.Body
+		case *ast.RangeStmt:
+			body = v.Body
+		}
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch v := n.(type) {
+			case *ast.FuncLit, *ast.ForStmt, *ast.RangeStmt:
+				// A break there doesn't concern this loop; nested loops are visited on their own.
+				return false
+			case *ast.SwitchStmt:
+				check("switch", v.Body.List)
+			case *ast.TypeSwitchStmt:
+				check("switch", v.Body.List)
+			case *ast.SelectStmt:
+				check("select", v.Body.List)
+			}
+			return true
+		})
+		return true
+	}, (*ast.ForStmt)(nil), (*ast.RangeStmt)(nil))
+}
+
+// lintRedundantContinue examines loop bodies.
+// It complains about an unlabeled continue as their last statement,
+// which does nothing. A labeled continue may go to an outer loop,
+// so it is left alone.
+func (f *file) lintRedundantContinue() {
+	f.visit(func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch v := n.(type) {
+		case *ast.ForStmt:
+			body = v.Body
+		case *ast.RangeStmt:
+			body = v.Body
+		}
+		if len(body.List) == 0 {
+			return true
+		}
+		if br, ok := body.List[len(body.List)-1].(*ast.BranchStmt); ok && br.Tok == token.CONTINUE && br.Label == nil {
+			f.errorf(br, 0.7, category("dead-code"), "continue at the end of a loop body is redundant; remove it")
+		}
+		return true
+	}, (*ast.ForStmt)(nil), (*ast.RangeStmt)(nil))
+}
+
+// lintTrailingBreak examines loop bodies.
+// It complains about loops whose body ends with an unlabeled break,
+// which makes them run at most once; that is often a mistake, and
+// otherwise an if statement says it better. A break in a switch or
+// select ends that instead, so only breaks directly in the body count.
+func (f *file) lintTrailingBreak() {
+	f.visit(func(n ast.Node) bool {
+		var body *ast.BlockStmt
+		switch v := n.(type) {
+		case *ast.ForStmt:
+			body = v.Body
+		case *ast.RangeStmt:
+			body = v.Body
+		}
+		if len(body.List) == 0 {
+			return true
+		}
+		if br, ok := body.List[len(body.List)-1].(*ast.BranchStmt); ok && br.Tok == token.BREAK && br.Label == nil {
+			f.errorf(n, 0.4, category("suspicious"), "loop body ends with a break at line %d, so the loop runs at most once", f.fset.Position(br.Pos()).Line)
+		}
+		return true
+	}, (*ast.ForStmt)(nil), (*ast.RangeStmt)(nil))
+}
+
+// printfFormatArgs maps the printf-like functions of packages fmt and log
+// to the index of their format argument.
+var printfFormatArgs = map[string]map[string]int{
+	"fmt": {"Printf": 0, "Sprintf": 0, "Errorf": 0, "Fprintf": 1},
+	"log": {"Printf": 0, "Fatalf": 0, "Panicf": 0},
+}
+
+// lintErrorVerb examines calls of printf-like functions of packages fmt and log.
+// It complains about errors formatted with a verb other than %v or %w,
+// like %s, which is less idiomatic and prints oddly for a nil error;
+// fmt.Errorf should wrap them with %w instead.
+func (f *file) lintErrorVerb() {
+	for path, funcs := range printfFormatArgs {
+		path, funcs := path, funcs // captured by the visitor
+		name := f.importName(path)
+		if name == "" {
+			continue
+		}
+		f.visit(func(n ast.Node) bool {
+			ce := n.(*ast.CallExpr)
+			sel, ok := ce.Fun.(*ast.SelectorExpr)
+			if !ok || !isIdent(sel.X, name) || ce.Ellipsis.IsValid() {
+				return true
+			}
+			i, ok := funcs[sel.Sel.Name]
+			if !ok || len(ce.Args) <= i {
+				return true
+			}
+			lit, ok := ce.Args[i].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			format, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+			verbs, ok := printfVerbs(format)
+			if !ok {
+				return true
+			}
+			should := "%v"
+			if path == "fmt" && sel.Sel.Name == "Errorf" {
+				should = "%w"
+			}
+			args := ce.Args[i+1:]
+			for j, verb := range verbs {
+				if j >= len(args) || verb == 'v' || verb == 'w' || verb == '*' || !f.isError(args[j]) {
+					continue
+				}
+				f.errorf(args[j], 0.5, category("errors"), "error %s is formatted with %%%c in %s.%s; use %s", f.lazyRender(args[j]), verb, name, sel.Sel.Name, should)
+			}
+			return true
+		}, (*ast.CallExpr)(nil))
+	}
+}
+
+// lintUseBeforeErrCheck examines assignments of several results, one of
+// which is an error, like v, err := f(). It complains about the first
+// use of another result in the statements that follow in the block, before
+// any of them uses the error, since the value may not be valid if there
+// was an error.
+func (f *file) lintUseBeforeErrCheck() {
+	check := func(list []ast.Stmt) {
+		for i, stmt := range list {
+			as, ok := stmt.(*ast.AssignStmt)
+			if !ok || len(as.Lhs) < 2 || len(as.Rhs) != 1 {
+				continue
+			}
+			var errObj *ast.Object
+			var values []*ast.Ident
+			for _, lhs := range as.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || isBlank(id) || id.Obj == nil {
+					continue
+				}
+				if f.isError(id) {
+					errObj = id.Obj
+				} else {
+					values = append(values, id)
+				}
+			}
+			if errObj == nil || len(values) == 0 {
+				continue
+			}
+		scan:
+			for _, later := range list[i+1:] {
+				if findObjRef(later, errObj) != nil {
+					break
+				}
+				for _, v := range values {
+					if ref := findObjRef(later, v.Obj); ref != nil {
+						f.errorf(ref, 0.3, category("errors"), "%s is used before %s is checked; it may not be valid if there was an error", v.Name, errObj.Name)
+						break scan
+					}
+				}
+			}
+		}
+	}
+	f.visit(func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.BlockStmt:
+			check(v.List)
+		case *ast.CaseClause:
+			check(v.Body)
+		case *ast.CommClause:
+			check(v.Body)
+		}
+		return true
+	}, (*ast.BlockStmt)(nil), (*ast.CaseClause)(nil), (*ast.CommClause)(nil))
+}
+
+// lintLargeSwitch examines switch statements on a value.
+// It complains about those with more than Config.MaxSwitchCases cases that
+// all just return or assign a simple value, such as a literal or constant,
+// which could be a lookup in a map instead.
+func (f *file) lintLargeSwitch() {
+	max := f.config.MaxSwitchCases
+	f.visit(func(n ast.Node) bool {
+		ss := n.(*ast.SwitchStmt)
+		if ss.Tag == nil {
+			return true
+		}
+		cases := 0
+		var lhs string
+		for _, stmt := range ss.Body.List {
+			cc := stmt.(*ast.CaseClause)
+			if cc.List == nil {
+				// The default case can be the result of a failed lookup.
+				continue
+			}
+			cases++
+			if len(cc.Body) != 1 {
+				return true
+			}
+			switch s := cc.Body[0].(type) {
+			case *ast.ReturnStmt:
+				if len(s.Results) != 1 || !isSimpleValue(s.Results[0]) || lhs != "" {
+					return true
+				}
+			case *ast.AssignStmt:
+				if len(s.Lhs) != 1 || len(s.Rhs) != 1 || s.Tok != token.ASSIGN || !isSimpleValue(s.Rhs[0]) {
+					return true
+				}
+				text := f.render(s.Lhs[0])
+				if lhs != "" && text != lhs || lhs == "" && cases > 1 {
+					return true
+				}
+				lhs = text
+			default:
+				return true
+			}
+		}
+		if cases > max {
+			f.errorf(ss, 0.3, category("control-flow"), "switch has %d cases that only map a value to another; consider a map lookup", cases)
+		}
+		return true
+	}, (*ast.SwitchStmt)(nil))
+}
+
+// isSimpleValue reports whether expr is a literal, possibly negated, or a
+// name, like a constant, possibly qualified.
+func isSimpleValue(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit, *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		_, ok := e.X.(*ast.Ident)
+		return ok
+	case *ast.UnaryExpr:
+		_, ok := e.X.(*ast.BasicLit)
+		return ok && e.Op == token.SUB
+	}
+	return false
+}
+
+// lintEnumStringer examines const blocks of a named type defined with iota.
+// It complains about those with at least three exported constants whose type
+// has no String method in the package, since the values then print as
+// numbers. Methods in other files are only known with LintFiles or LintDir.
+func (f *file) lintEnumStringer() {
+	f.visit(func(n ast.Node) bool {
+		gd, ok := n.(*ast.GenDecl)
+		if !ok || gd.Tok != token.CONST || !gd.Lparen.IsValid() {
+			// Enums are declared in parenthesized blocks. Returning false
+			// for a FuncDecl skips the constants declared in functions.
+			return false
+		}
+		// Constants without a type and values repeat the ones before them.
+		var typ *ast.Ident
+		var usesIota bool
+		enums := make(map[string]int)
+		// first maps the spec of the first exported constant of each type
+		// to the type, which the spec may only repeat.
+		first := make(map[*ast.ValueSpec]string)
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if vs.Type != nil || vs.Values != nil {
+				typ, _ = vs.Type.(*ast.Ident)
+				usesIota = false
+				for _, v := range vs.Values {
+					usesIota = usesIota || findIota(v)
+				}
+			}
+			if typ == nil || typ.Obj == nil || typ.Obj.Kind != ast.Typ || !usesIota {
+				continue
+			}
+			for _, name := range vs.Names {
+				if name.IsExported() {
+					if enums[typ.Name] == 0 {
+						first[vs] = typ.Name
+					}
+					enums[typ.Name]++
+				}
+			}
+		}
+		for _, spec := range gd.Specs {
+			// Report in the order of the block.
+			vs := spec.(*ast.ValueSpec)
+			name, ok := first[vs]
+			if !ok || enums[name] < 3 || f.hasStringMethod(name) {
+				continue
+			}
+			f.errorf(vs, 0.4, category("enums"), "enum type %s has %d exported constants but no String method; consider adding one or generating it with stringer -type=%s", name, enums[name], name)
+		}
+		return false
+	}, (*ast.GenDecl)(nil), (*ast.FuncDecl)(nil))
+}
+
+// findIota reports whether expr refers to iota.
+func findIota(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == "iota" && id.Obj == nil {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// hasStringMethod reports whether the type with the given name has a
+// String() string method in the package.
+func (f *file) hasStringMethod(typ string) bool {
+	fn, ok := f.pkg.methods[typ+".String"]
+	if !ok {
+		return false
+	}
+	ft := fn.Type
+	return ft.Params.NumFields() == 0 && ft.Results.NumFields() == 1 && isIdent(ft.Results.List[0].Type, "string")
+}
+
+// lintMissingNewline examines fmt.Print and fmt.Printf calls in main packages.
+// It complains, as a hint, about those whose output ends with a string
+// literal without a trailing newline, which can leave the terminal prompt
+// on the same line. Output ending with a space, like a prompt, or with
+// a carriage return, like a progress line, is left alone.
+func (f *file) lintMissingNewline() {
+	fmtName := f.importName("fmt")
+	if !f.main || fmtName == "" {
+		return
+	}
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		var last ast.Expr
+		switch {
+		case isPkgDot(ce.Fun, fmtName, "Printf") && len(ce.Args) > 0:
+			last = ce.Args[0]
+		case isPkgDot(ce.Fun, fmtName, "Print") && len(ce.Args) > 0:
+			last = ce.Args[len(ce.Args)-1]
+		default:
+			return true
+		}
+		lit, ok := last.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil || s == "" || strings.ContainsAny(s[len(s)-1:], "\n\r ") {
+			return true
+		}
+		f.errorf(ce, 0.2, category("output"), "output of %s doesn't end with a newline; consider adding \\n or using %s.Println", f.lazyRender(ce.Fun), fmtName)
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// lintReturnInterface examines the results of exported functions.
+// It complains, as a hint, about interfaces declared in the package that
+// only one type of the package implements, since callers then have to
+// type-assert to get at the rest of its API; the concrete type could be
+// returned instead. Implementations are found more precisely with
+// Config.TypeCheck; without it, only the method names are compared.
+func (f *file) lintReturnInterface() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if !fn.Name.IsExported() || fn.Type.Results == nil {
+			return false
+		}
+		if fn.Recv != nil && !ast.IsExported(receiverType(fn)) {
+			return false
+		}
+		for _, field := range fn.Type.Results.List {
+			id, ok := field.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			impl, ok := f.pkg.implementers[id.Name]
+			if !ok || len(impl) != 1 {
+				continue
+			}
+			f.errorf(field.Type, 0.3, category("api-design"), "exported %s returns interface %s, which only %s implements; consider returning %s (accept interfaces, return structs)", fn.Name.Name, id.Name, impl[0], impl[0])
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintRedundantLabel examines labeled for, switch and select statements.
+// It complains about a break or continue naming the label of the statement
+// it would leave or continue anyway, where the bare form is equivalent.
+// A labeled break or continue in a nested loop, switch or select that
+// jumps out of it is needed, so it is left alone.
+// If the label has no other uses, the message says to remove it too.
+func (f *file) lintRedundantLabel() {
+	f.visit(func(n ast.Node) bool {
+		ls := n.(*ast.LabeledStmt)
+		var loop bool
+		switch ls.Stmt.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			loop = true
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+		default:
+			return true
+		}
+		label := ls.Label.Name
+		var redundant []*ast.BranchStmt
+		// brk and cont are whether a bare break or continue would
+		// target the labeled statement.
+		var walk func(root ast.Node, brk, cont bool)
+		walk = func(root ast.Node, brk, cont bool) {
+			ast.Inspect(root, func(n ast.Node) bool {
+				if n == root {
+					return true
+				}
+				switch v := n.(type) {
+				case *ast.FuncLit, *ast.ForStmt, *ast.RangeStmt:
+					return false
+				case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+					if cont {
+						walk(v, false, true)
+					}
+					return false
+				case *ast.BranchStmt:
+					if v.Label == nil || v.Label.Name != label {
+						return false
+					}
+					if v.Tok == token.BREAK && brk || v.Tok == token.CONTINUE && cont {
+						redundant = append(redundant, v)
+					}
+					return false
+				}
+				return true
+			})
+		}
+		walk(ls.Stmt, true, loop)
+		if len(redundant) == 0 {
+			return true
+		}
+		// A goto may use the label from anywhere in the function.
+		var scope ast.Node = ls
+		if fn, ok := f.decl.(*ast.FuncDecl); ok {
+			scope = fn
+		}
+		uses := 0
+		ast.Inspect(scope, func(n ast.Node) bool {
+			if bs, ok := n.(*ast.BranchStmt); ok && bs.Label != nil && bs.Label.Name == label {
+				uses++
+			}
+			return true
+		})
+		for _, v := range redundant {
+			if uses == len(redundant) {
+				f.errorf(v.Label, 0.7, category("control-flow"), "%s %s is redundant: %s labels the innermost statement it applies to; use a bare %s and remove the label, it has no other uses", v.Tok, label, label, v.Tok)
+			} else {
+				f.errorf(v.Label, 0.7, category("control-flow"), "%s %s is redundant: %s labels the innermost statement it applies to; use a bare %s", v.Tok, label, label, v.Tok)
+			}
+		}
+		return true
+	}, (*ast.LabeledStmt)(nil))
+}
+
+// lintDuplicateConstValue examines const blocks.
+// It complains, as a hint, about exported constants of a block with the
+// same literal value and type as an earlier one, which may be a copy-paste
+// error; if they are meant to be equal, defining one in terms of the other
+// says so. Blocks using iota are skipped.
+func (f *file) lintDuplicateConstValue() {
+	f.visit(func(n ast.Node) bool {
+		gd := n.(*ast.GenDecl)
+		if gd.Tok != token.CONST || len(gd.Specs) < 2 {
+			return false
+		}
+		for _, spec := range gd.Specs {
+			for _, val := range spec.(*ast.ValueSpec).Values {
+				if findIota(val) {
+					return false
+				}
+			}
+		}
+		seen := make(map[string]*ast.Ident) // type, kind and value -> first name
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			if len(vs.Names) != len(vs.Values) {
+				continue
+			}
+			for i, name := range vs.Names {
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || !name.IsExported() {
+					continue
+				}
+				key := lit.Kind.String() + " " + constant.MakeFromLiteral(lit.Value, lit.Kind, 0).ExactString()
+				if vs.Type != nil {
+					key = f.render(vs.Type) + " " + key
+				}
+				if first, ok := seen[key]; ok {
+					f.errorf(name, 0.3, category("const"), "exported const %s has the same value %s as %s; if they are meant to be equal, define %s as %s", name.Name, lit.Value, first.Name, name.Name, first.Name)
+					continue
+				}
+				seen[key] = name
+			}
+		}
+		return false
+	}, (*ast.GenDecl)(nil))
+}
+
+// lintUnusedError examines functions.
+// It complains about errors made with errors.New or fmt.Errorf and assigned
+// to a local variable when that value is never read: neither returned,
+// logged nor compared, the error is dead code, and likely meant to be
+// returned. Each assignment is examined on its own, following the next
+// reference to the variable: the value is lost if there is none, or if it
+// is an assignment in the same block that doesn't read the variable.
+// Variables referred to in func literals or whose address is taken are
+// skipped, as are named results, since a bare return reads them, and
+// values that a loop may read in its next iteration.
+func (f *file) lintUnusedError() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Body == nil {
+			return false
+		}
+		// parent maps statements to the block or clause holding them.
+		parent := make(map[ast.Stmt]ast.Node)
+		// writes maps the identifiers assigned to to their assignment;
+		// declared holds those declared with var.
+		writes := make(map[*ast.Ident]*ast.AssignStmt)
+		declared := make(map[*ast.Ident]bool)
+		escaped := make(map[*ast.Object]bool)
+		var loops []ast.Node
+		var defs []*ast.AssignStmt
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			var list []ast.Stmt
+			switch v := n.(type) {
+			case *ast.BlockStmt:
+				list = v.List
+			case *ast.CaseClause:
+				list = v.Body
+			case *ast.CommClause:
+				list = v.Body
+			case *ast.ForStmt, *ast.RangeStmt:
+				loops = append(loops, v)
+			case *ast.FuncLit:
+				ast.Inspect(v.Body, func(n ast.Node) bool {
+					if id, ok := n.(*ast.Ident); ok && id.Obj != nil {
+						escaped[id.Obj] = true
+					}
+					return true
+				})
+			case *ast.UnaryExpr:
+				if id, ok := v.X.(*ast.Ident); ok && v.Op == token.AND && id.Obj != nil {
+					escaped[id.Obj] = true
+				}
+			case *ast.ValueSpec:
+				for _, name := range v.Names {
+					declared[name] = true
+				}
+			case *ast.AssignStmt:
+				if v.Tok != token.ASSIGN && v.Tok != token.DEFINE {
+					break
+				}
+				for _, lhs := range v.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						writes[id] = v
+					}
+				}
+				if len(v.Lhs) == 1 && len(v.Rhs) == 1 && isErrorConstructor(v.Rhs[0]) {
+					if id, ok := v.Lhs[0].(*ast.Ident); ok && !isBlank(id) && id.Obj != nil {
+						defs = append(defs, v)
+					}
+				}
+			}
+			for _, stmt := range list {
+				parent[stmt] = n
+			}
+			return true
+		})
+		isRead := func(id *ast.Ident) bool { return writes[id] == nil && !declared[id] }
+
+		for _, as := range defs {
+			id := as.Lhs[0].(*ast.Ident)
+			obj := id.Obj
+			decl, ok := obj.Decl.(ast.Node)
+			if !ok || decl.Pos() < fn.Body.Pos() || decl.Pos() >= fn.Body.End() || escaped[obj] {
+				// Not a local variable, or one that may be read elsewhere.
+				continue
+			}
+			var next *ast.Ident
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				if ref, ok := n.(*ast.Ident); ok && ref.Obj == obj && ref.Pos() > as.End() && next == nil {
+					next = ref
+				}
+				return next == nil
+			})
+			if next == nil {
+				readInLoop := false
+				for _, loop := range loops {
+					if loop.Pos() > decl.Pos() && loop.Pos() < as.Pos() && as.End() <= loop.End() {
+						ast.Inspect(loop, func(n ast.Node) bool {
+							if ref, ok := n.(*ast.Ident); ok && ref.Obj == obj && isRead(ref) {
+								readInLoop = true
+							}
+							return !readInLoop
+						})
+					}
+				}
+				if !readInLoop {
+					f.errorf(id, 0.6, category("errors"), "error assigned to %s is never used; return or handle it, or remove it", id.Name)
+				}
+				continue
+			}
+			w := writes[next]
+			if w == nil || parent[w] != parent[as] {
+				// A read, or an assignment that may not happen.
+				continue
+			}
+			readByW := false
+			for _, rhs := range w.Rhs {
+				readByW = readByW || findObjRef(rhs, obj) != nil
+			}
+			if !readByW {
+				f.errorf(id, 0.6, category("errors"), "error assigned to %s is overwritten before it is used; return or handle it, or remove it", id.Name)
+			}
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintHandlerSignature examines top-level functions meant as HTTP handlers:
+// those passed to a HandleFunc call in the package, like http.HandleFunc or
+// mux.HandleFunc, and those named with a Handler suffix that take an
+// http.ResponseWriter or *http.Request and return nothing. It complains
+// about those whose signature isn't func(http.ResponseWriter, *http.Request).
+func (f *file) lintHandlerSignature() {
+	httpName := f.importName("net/http")
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Recv != nil {
+			return false
+		}
+		params := fieldTypes(fn.Type.Params)
+		isWriter := func(expr ast.Expr) bool {
+			return httpName != "" && isPkgDot(expr, httpName, "ResponseWriter")
+		}
+		isRequest := func(expr ast.Expr) bool {
+			star, ok := expr.(*ast.StarExpr)
+			return ok && httpName != "" && isPkgDot(star.X, httpName, "Request")
+		}
+		if len(params) == 2 && isWriter(params[0]) && isRequest(params[1]) && fn.Type.Results == nil {
+			return false
+		}
+		if !f.pkg.handlerFuncs[fn.Name.Name] {
+			if !strings.HasSuffix(fn.Name.Name, "Handler") || fn.Type.Results != nil {
+				return false
+			}
+			usesHTTP := false
+			for _, typ := range params {
+				usesHTTP = usesHTTP || isWriter(typ) || isRequest(typ)
+			}
+			if !usesHTTP {
+				return false
+			}
+		}
+		f.errorf(fn.Type, 0.6, category("http"), "%s looks like an HTTP handler but its signature is not func(http.ResponseWriter, *http.Request)", fn.Name.Name)
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// lintThinWrapper examines exported functions.
+// It complains, as a hint, about one whose body only returns the result of
+// calling an unexported function of the package with the same signature,
+// passing its parameters on unchanged: the indirection is unneeded, unless
+// documented for a reason.
+func (f *file) lintThinWrapper() {
+	f.visit(func(n ast.Node) bool {
+		fn := n.(*ast.FuncDecl)
+		if fn.Recv != nil || !fn.Name.IsExported() || fn.Type.TypeParams != nil || fn.Body == nil || len(fn.Body.List) != 1 {
+			return false
+		}
+		ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return false
+		}
+		ce, ok := ret.Results[0].(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		callee, ok := ce.Fun.(*ast.Ident)
+		if !ok || callee.IsExported() {
+			return false
+		}
+		params := fieldTypes(fn.Type.Params)
+		var names []*ast.Ident
+		for _, field := range fn.Type.Params.List {
+			names = append(names, field.Names...)
+		}
+		if len(names) != len(params) || len(ce.Args) != len(names) {
+			return false
+		}
+		for i, arg := range ce.Args {
+			if isBlank(names[i]) || !isIdent(arg, names[i].Name) {
+				return false
+			}
+		}
+		if len(params) > 0 {
+			_, variadic := params[len(params)-1].(*ast.Ellipsis)
+			if variadic != ce.Ellipsis.IsValid() {
+				return false
+			}
+		}
+		var decl *ast.FuncDecl
+		for _, pf := range f.pkg.files {
+			for _, d := range pf.f.Decls {
+				if fd, ok := d.(*ast.FuncDecl); ok && fd.Recv == nil && fd.Name.Name == callee.Name && fd.Type.TypeParams == nil {
+					decl = fd
+				}
+			}
+		}
+		if decl == nil {
+			return false
+		}
+		same := func(a, b *ast.FieldList) bool {
+			at, bt := fieldTypes(a), fieldTypes(b)
+			if len(at) != len(bt) {
+				return false
+			}
+			for i := range at {
+				if f.render(at[i]) != f.render(bt[i]) {
+					return false
+				}
+			}
+			return true
+		}
+		if same(fn.Type.Params, decl.Type.Params) && same(fn.Type.Results, decl.Type.Results) {
+			f.errorf(fn.Name, 0.2, category("api"), "exported %s only calls %s, which has the same signature; consider merging them, or document what the wrapper is for", fn.Name.Name, callee.Name)
+		}
+		return false
+	}, (*ast.FuncDecl)(nil))
+}
+
+// scanRecoveredTypes records the types that recovered values are
+// type-asserted to in the file, in f.recoveredTypes, once for all the
+// declarations examined by lintPanicControlFlow.
+func (f *file) scanRecoveredTypes() {
+	isRecover := func(expr ast.Expr) bool {
+		ce, ok := expr.(*ast.CallExpr)
+		return ok && isIdent(ce.Fun, "recover") && len(ce.Args) == 0
+	}
+	// recovered reports whether expr is a call to recover or a variable
+	// assigned the result of one.
+	recovered := func(expr ast.Expr) bool {
+		if isRecover(expr) {
+			return true
+		}
+		id, ok := expr.(*ast.Ident)
+		if !ok || id.Obj == nil {
+			return false
+		}
+		var values []ast.Expr
+		switch d := id.Obj.Decl.(type) {
+		case *ast.AssignStmt:
+			values = d.Rhs
+		case *ast.ValueSpec:
+			values = d.Values
+		}
+		return len(values) == 1 && isRecover(values[0])
+	}
+	f.recoveredTypes = make(map[string]bool)
+	ast.Inspect(f.f, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.TypeAssertExpr:
+			if v.Type != nil && recovered(v.X) {
+				f.recoveredTypes[f.render(v.Type)] = true
+			}
+		case *ast.TypeSwitchStmt:
+			var ta *ast.TypeAssertExpr
+			switch a := v.Assign.(type) {
+			case *ast.ExprStmt:
+				ta, _ = a.X.(*ast.TypeAssertExpr)
+			case *ast.AssignStmt:
+				ta, _ = a.Rhs[0].(*ast.TypeAssertExpr)
+			}
+			if ta == nil || !recovered(ta.X) {
+				return true
+			}
+			for _, stmt := range v.Body.List {
+				for _, typ := range stmt.(*ast.CaseClause).List {
+					if !isIdent(typ, "nil") {
+						f.recoveredTypes[f.render(typ)] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// lintPanicControlFlow examines panic calls.
+// It complains, as a hint, about panicking with a value of a type that a
+// recovered value is type-asserted to in the same file, which suggests the
+// panic is a deliberate non-local jump, caught elsewhere like an exception.
+// Go code returns errors for that. Types are compared as written; the type
+// of a panic value is only known for composite literals, conversions and
+// variables declared with one of these or with a type.
+func (f *file) lintPanicControlFlow() {
+	if len(f.recoveredTypes) == 0 {
+		return
+	}
+
+	f.visit(func(n ast.Node) bool {
+		ce := n.(*ast.CallExpr)
+		if !isIdent(ce.Fun, "panic") || len(ce.Args) != 1 {
+			return true
+		}
+		typ := f.valueType(ce.Args[0])
+		if typ != "" && f.recoveredTypes[typ] {
+			f.errorf(ce, 0.2, category("robustness"), "panic with a %s value recovered elsewhere in this file uses panics for control flow; return an error instead", typ)
+		}
+		return true
+	}, (*ast.CallExpr)(nil))
+}
+
+// valueType returns the type of the value expr as written, if it is a
+// composite literal, a pointer to one, a conversion to a type declared in
+// the file, or a variable declared with a type or one of these, or "".
+func (f *file) valueType(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		if lit, ok := e.X.(*ast.CompositeLit); ok && e.Op == token.AND && lit.Type != nil {
+			return "*" + f.render(lit.Type)
+		}
+	case *ast.CompositeLit:
+		if e.Type != nil {
+			return f.render(e.Type)
+		}
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok && id.Obj != nil && id.Obj.Kind == ast.Typ && len(e.Args) == 1 {
+			return id.Name
+		}
+	case *ast.Ident:
+		if e.Obj == nil || e.Obj.Kind != ast.Var {
+			return ""
+		}
+		var lhs []ast.Expr
+		var rhs []ast.Expr
+		switch d := e.Obj.Decl.(type) {
+		case *ast.Field:
+			return f.render(d.Type)
+		case *ast.ValueSpec:
+			if d.Type != nil {
+				return f.render(d.Type)
+			}
+			for _, name := range d.Names {
+				lhs = append(lhs, name)
+			}
+			rhs = d.Values
+		case *ast.AssignStmt:
+			lhs, rhs = d.Lhs, d.Rhs
+		}
+		if len(lhs) != len(rhs) {
+			return ""
+		}
+		for i, l := range lhs {
+			if id, ok := l.(*ast.Ident); ok && id.Obj == e.Obj {
+				if _, ok := rhs[i].(*ast.Ident); !ok {
+					return f.valueType(rhs[i])
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// importName returns the name by which the package with the given import path
+// is referred to in the file, or "" if it is not imported.
+func (f *file) importName(path string) string {
+	for _, is := range f.f.Imports {
+		p, err := strconv.Unquote(is.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+		if is.Name != nil {
+			if is.Name.Name == "_" || is.Name.Name == "." {
+				return ""
+			}
+			return is.Name.Name
+		}
+		return p[strings.LastIndex(p, "/")+1:]
+	}
+	return ""
+}
+
+// findObjRef returns the first identifier in n that refers to obj.
+func findObjRef(n ast.Node, obj *ast.Object) *ast.Ident {
+	if obj == nil {
+		return nil
+	}
+	var ref *ast.Ident
+	ast.Inspect(n, func(n ast.Node) bool {
+		if ref != nil {
+			return false
+		}
+		if id, ok := n.(*ast.Ident); ok && id.Obj == obj {
+			ref = id
+		}
+		return true
+	})
+	return ref
+}
+
+// goVersionAtLeast reports whether the Go version v (e.g. "1.21", "go1.22.3")
+// is at least major.minor. An empty or malformed version is treated as older.
+func goVersionAtLeast(v string, major, minor int) bool {
+	parts := strings.SplitN(strings.TrimPrefix(v, "go"), ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	vmajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	vminor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return vmajor > major || vmajor == major && vminor >= minor
+}
+
+// receiverType returns the name of the type of the method fn's receiver,
+// without any type arguments, so it is "Stack" for (s *Stack[T]).
+// It returns "" for receivers the compiler would reject,
+// such as an empty or repeated receiver or a qualified type.
+func receiverType(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return ""
+	}
+	typ := fn.Recv.List[0].Type
+	for {
+		switch e := typ.(type) {
+		case *ast.Ident:
+			return e.Name
+		case *ast.StarExpr:
+			typ = e.X
+		case *ast.ParenExpr:
+			typ = e.X
+		case *ast.IndexExpr:
+			typ = e.X
+		case *ast.IndexListExpr:
+			typ = e.X
+		default:
+			return ""
+		}
+	}
+}
+
+// fix returns a suggested fix replacing the source between pos and end with newText.
+func (f *file) fix(pos, end token.Pos, newText string) *SuggestedFix {
+	return &SuggestedFix{Pos: f.fset.Position(pos), End: f.fset.Position(end), NewText: newText}
+}
+
+// visit registers fn to be called, during the walk of the top-level
+// declarations in scope (see file.decls) that runChecks does once all the
+// checks are registered, for the nodes of the same types as nodes,
+// or for all nodes if none are given.
+// As with ast.Inspect, fn returns whether it should be called for the
+// children of the node.
+func (f *file) visit(fn func(ast.Node) bool, nodes ...ast.Node) {
+	d := f.dispatcher
+	if d == nil {
+		d = dispatchers.Get().(*dispatcher)
+		d.f = f
+		f.dispatcher = d
+	}
+	v := d.newVisitor()
+	v.fn, v.before = fn, f.problems
+	if len(nodes) == 0 {
+		d.all = append(d.all, v)
+	}
+	for _, n := range nodes {
+		t := reflect.TypeOf(n)
+		d.byType[t] = append(d.byType[t], v)
+	}
+	f.problems = nil
+}
+
+// walkVisitors walks the top-level declarations in scope once, passing each
+// node to the visitors interested in it. The problems are then arranged
+// in the order the checks were run, as if each check had walked on its own.
+func (f *file) walkVisitors() {
+	d := f.dispatcher
+	if d == nil {
+		return
+	}
+
+	after := f.problems
+	for _, decl := range f.decls {
+		f.decl = decl
+		ast.Walk(d, decl)
+	}
+	f.decl = nil
+
+	var problems []Problem
+	for _, v := range d.visitors {
+		problems = append(problems, v.before...)
+		problems = append(problems, v.problems...)
+	}
+	f.problems = append(problems, after...)
+
+	d.reset()
+	dispatchers.Put(d)
+	f.dispatcher = nil
+}
+
+func (f *file) render(x interface{}) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, f.fset, x); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+func (f *file) debugRender(x interface{}) string {
+	var buf bytes.Buffer
+	if err := ast.Fprint(&buf, f.fset, x, nil); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+// visitor is a check registered with file.visit.
+type visitor struct {
+	fn func(ast.Node) bool
+
+	// before holds the problems reported by the checks run before the
+	// visitor was registered, and problems holds those reported by fn.
+	before, problems []Problem
+
+	// skipDepth is the depth of the node whose children fn doesn't want
+	// to be called for, or -1.
+	skipDepth int
+}
+
+// dispatcher is the ast.Visitor used by file.walkVisitors.
+// Dispatchers are pooled, so linting many files reuses their memory.
+type dispatcher struct {
+	f        *file
+	visitors []*visitor // in the order they were registered
+	spare    []*visitor // visitors to reuse
+	all      []*visitor
+	byType   map[reflect.Type][]*visitor
+
+	depth int // depth of the next node, relative to the declaration
+	// skipping holds the visitors with skipDepth set, in the order they were set,
+	// so the ones to resume when leaving a node are at the end.
+	skipping []*visitor
+}
+
+func (d *dispatcher) Visit(node ast.Node) ast.Visitor {
+	if node == nil {
+		d.depth--
+		d.resume()
+		return nil
+	}
+	d.call(d.all, node)
+	d.call(d.byType[reflect.TypeOf(node)], node)
+	if len(d.skipping) == len(d.visitors) {
+		// Nobody wants the children.
+		d.resume()
+		return nil
+	}
+	d.depth++
+	return d
+}
+
+func (d *dispatcher) call(visitors []*visitor, node ast.Node) {
+	for _, v := range visitors {
+		if v.skipDepth >= 0 {
+			continue
+		}
+		d.f.problems = v.problems
+		if !v.fn(node) {
+			v.skipDepth = d.depth
+			d.skipping = append(d.skipping, v)
+		}
+		v.problems = d.f.problems
+	}
+}
+
+var dispatchers = sync.Pool{
+	New: func() interface{} {
+		return &dispatcher{byType: make(map[reflect.Type][]*visitor)}
+	},
+}
+
+// newVisitor returns a new visitor, registered last.
+func (d *dispatcher) newVisitor() *visitor {
+	var v *visitor
+	if n := len(d.spare); n > 0 {
+		v, d.spare = d.spare[n-1], d.spare[:n-1]
+	} else {
+		v = new(visitor)
+	}
+	v.skipDepth = -1
+	d.visitors = append(d.visitors, v)
+	return v
+}
+
+// reset prepares d to be used for another file, keeping the memory it allocated.
+func (d *dispatcher) reset() {
+	for _, v := range d.visitors {
+		*v = visitor{problems: v.problems[:0]}
+	}
+	d.spare = append(d.spare, d.visitors...)
+	d.visitors = clearVisitors(d.visitors)
+	d.all = clearVisitors(d.all)
+	for t, vs := range d.byType {
+		d.byType[t] = clearVisitors(vs)
+	}
+	d.f = nil
+	d.depth = 0
+	d.skipping = d.skipping[:0]
+}
+
+// clearVisitors empties vs, not keeping references to the visitors.
+func clearVisitors(vs []*visitor) []*visitor {
+	for i := range vs {
+		vs[i] = nil
+	}
+	return vs[:0]
+}
+
+// resume resumes the visitors that skipped the children of the node at the current depth.
+func (d *dispatcher) resume() {
+	for len(d.skipping) > 0 {
+		v := d.skipping[len(d.skipping)-1]
+		if v.skipDepth != d.depth {
+			break
+		}
+		v.skipDepth = -1
+		d.skipping = d.skipping[:len(d.skipping)-1]
+	}
+}
+
+func isIdent(expr ast.Expr, ident string) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == ident
+}
+
+// fieldTypes returns the type of each field of fl, which may be nil,
+// repeating the type of fields declared together, like a and b in (a, b int).
+func fieldTypes(fl *ast.FieldList) []ast.Expr {
+	if fl == nil {
+		return nil
+	}
+	var fts []ast.Expr
+	for _, field := range fl.List {
+		fts = append(fts, field.Type)
+		for i := 1; i < len(field.Names); i++ {
+			fts = append(fts, field.Type)
+		}
+	}
+	return fts
 }
 
 // isBlank returns whether id is the blank identifier "_".
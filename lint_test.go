@@ -8,13 +8,17 @@ package hint
 
 import (
 	"bytes"
+	"encoding/json"
 	"flag"
 	"go/parser"
 	"go/printer"
 	"go/token"
 	"io/ioutil"
 	"path"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -53,7 +57,8 @@ func TestAll(t *testing.T) {
 		}
 
 		config := NewDefaultConfig()
-		config.MinConfidence = 0    // do not ignore any errors because of confidence threshold
+		config.MinConfidence = 0 // do not ignore any errors because of confidence threshold
+		parseConfig(t, fi.Name(), src, config)
 		ps, err := l.Lint(fi.Name(), config, src)
 		if err != nil {
 			t.Errorf("Linting %s: %v", fi.Name(), err)
@@ -86,6 +91,159 @@ func TestAll(t *testing.T) {
 	}
 }
 
+// TestDeclOrder checks that linting in declaration order finds the same
+// problems as the default mode, and reports them in source order.
+func TestDeclOrder(t *testing.T) {
+	l := new(Linter)
+	baseDir := "testdata"
+	fis, err := ioutil.ReadDir(baseDir)
+	if err != nil {
+		t.Fatalf("ioutil.ReadDir: %v", err)
+	}
+	for _, fi := range fis {
+		src, err := ioutil.ReadFile(path.Join(baseDir, fi.Name()))
+		if err != nil {
+			t.Fatalf("Failed reading %s: %v", fi.Name(), err)
+		}
+		config := NewDefaultConfig()
+		config.MinConfidence = 0
+		parseConfig(t, fi.Name(), src, config)
+		want, err := l.Lint(fi.Name(), config, src)
+		if err != nil {
+			t.Errorf("Linting %s: %v", fi.Name(), err)
+			continue
+		}
+		config.DeclOrder = true
+		got, err := l.Lint(fi.Name(), config, src)
+		if err != nil {
+			t.Errorf("Linting %s: %v", fi.Name(), err)
+			continue
+		}
+		if !sort.IsSorted(byPosition(got)) {
+			t.Errorf("%s: problems are not in source order", fi.Name())
+		}
+		sort.Stable(byPosition(want))
+		if len(got) != len(want) {
+			t.Errorf("%s: got %d problems in declaration order, want %d", fi.Name(), len(got), len(want))
+			continue
+		}
+		for i := range want {
+			if got[i].Position != want[i].Position || got[i].Text != want[i].Text {
+				t.Errorf("%s: problem %d is %v: %q, want %v: %q", fi.Name(), i, got[i].Position, got[i].Text, want[i].Position, want[i].Text)
+			}
+		}
+	}
+}
+
+func TestLintFunc(t *testing.T) {
+	src := []byte(`package foo
+
+var a_b int
+
+func f() (x int, err error) {
+	x += 1
+	return
+}
+
+var c_d = 1
+`)
+	l := new(Linter)
+	for _, declOrder := range []bool{false, true} {
+		config := NewDefaultConfig()
+		config.DeclOrder = declOrder
+		want, err := l.Lint("foo.go", config, src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []Problem
+		err = l.LintFunc("foo.go", config, src, func(p Problem) {
+			got = append(got, p)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("DeclOrder=%v: LintFunc reported %v, Lint returned %v", declOrder, got, want)
+		}
+	}
+
+	err := l.LintFunc("bad.go", nil, []byte("package"), func(p Problem) {
+		t.Errorf("unexpected problem %v", p)
+	})
+	if err == nil {
+		t.Error("LintFunc of invalid source: got nil error")
+	}
+}
+
+func TestNormalizePaths(t *testing.T) {
+	src := []byte("package foo\n\nvar a_b int\n")
+	files := map[string][]byte{`pkg\sub/foo.go`: src}
+	config := NewDefaultConfig()
+	for _, normalize := range []bool{false, true} {
+		config.NormalizePaths = normalize
+		ps, err := new(Linter).LintFiles(files, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `pkg\sub/foo.go`
+		if normalize {
+			want = "pkg/sub/foo.go"
+		}
+		for _, p := range ps {
+			if p.File != want {
+				t.Errorf("NormalizePaths=%v: Problem.File = %q, want %q", normalize, p.File, want)
+			}
+		}
+		if len(ps) == 0 {
+			t.Errorf("NormalizePaths=%v: got no problems", normalize)
+		}
+	}
+}
+
+func TestSortProblems(t *testing.T) {
+	src := []byte(`package foo
+
+var a_b int = 0
+
+func f() (x int, err error) {
+	x += 1
+	return
+}
+`)
+	l := new(Linter)
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	config.SortProblems = true
+	ps, err := l.Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sort.IsSorted(byPosition(ps)) {
+		t.Errorf("SortProblems: problems are not sorted by position")
+	}
+
+	config.SortProblems = false
+	config.SortByConfidence = true
+	for _, declOrder := range []bool{false, true} {
+		config.DeclOrder = declOrder
+		ps, err := l.Lint("foo.go", config, src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ps) < 3 {
+			t.Fatalf("DeclOrder=%v: got %d problems, want at least 3", declOrder, len(ps))
+		}
+		if !declOrder && !sort.IsSorted(byConfidencePosition(ps)) {
+			t.Errorf("SortByConfidence: problems are not sorted by confidence")
+		}
+		for i := 1; declOrder && i < len(ps); i++ {
+			if ps[i].Position.Line == ps[i-1].Position.Line && ps[i].Confidence > ps[i-1].Confidence {
+				t.Errorf("SortByConfidence with DeclOrder: problem %d has higher confidence than problem %d of the same declaration", i, i-1)
+			}
+		}
+	}
+}
+
 type instruction struct {
 	Line  int            // the line number this applies to
 	Match *regexp.Regexp // what pattern to match
@@ -122,8 +280,18 @@ func parseInstructions(t *testing.T, filename string, src []byte) []instruction
 				if err != nil {
 					t.Fatalf("Bad match pattern %q at %v:%d: %v", pat, filename, ln, err)
 				}
+				matchLine := ln
+				if i := strings.Index(line, "MATCH:"); i >= 0 {
+					// This is a match for a different line.
+					lns := strings.TrimPrefix(line[i:], "MATCH:")
+					lns = lns[:strings.Index(lns, " ")]
+					matchLine, err = strconv.Atoi(lns)
+					if err != nil {
+						t.Fatalf("Bad match line number %q at %v:%d: %v", lns, filename, ln, err)
+					}
+				}
 				ins = append(ins, instruction{
-					Line:  ln,
+					Line:  matchLine,
 					Match: rx,
 				})
 			}
@@ -132,6 +300,27 @@ func parseInstructions(t *testing.T, filename string, src []byte) []instruction
 	return ins
 }
 
+// parseConfig applies CONFIG instructions from the comments in a Go source file
+// to config. Each instruction is a JSON object in the same form as a config file,
+// e.g. CONFIG {"use-this": true}.
+func parseConfig(t *testing.T, filename string, src []byte, config *Config) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Test file %v does not parse: %v", filename, err)
+	}
+	for _, cg := range f.Comments {
+		for _, line := range strings.Split(cg.Text(), "\n") {
+			if !strings.HasPrefix(line, "CONFIG ") {
+				continue
+			}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "CONFIG ")), config); err != nil {
+				t.Fatalf("Bad config instruction %q at %v:%d: %v", line, filename, fset.Position(cg.Pos()).Line, err)
+			}
+		}
+	}
+}
+
 func render(fset *token.FileSet, x interface{}) string {
 	var buf bytes.Buffer
 	if err := printer.Fprint(&buf, fset, x); err != nil {
@@ -189,8 +378,10 @@ func TestLintName(t *testing.T) {
 		{"a__b", "aB"},
 		{"a___b", "aB"},
 		{"Rpc1150", "RPC1150"},
+		{"aVeryLongNameThatDoesNotFitInTheBufferFixNameStartsWithForUserId", "aVeryLongNameThatDoesNotFitInTheBufferFixNameStartsWithForUserID"},
 	}
-	f := file{config: NewDefaultConfig()}
+	config := NewDefaultConfig()
+	f := file{pkg: &pkg{initialisms: upperSet(config.Initialisms)}, config: config}
 	for _, test := range tests {
 		got := f.fixName(test.name)
 		if got != test.want {
@@ -198,3 +389,22 @@ func TestLintName(t *testing.T) {
 		}
 	}
 }
+
+func TestLintNameCustomInitialisms(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Initialisms = map[string]bool{"ID": true, "grpc": true, "Ok": false}
+	f := file{pkg: &pkg{initialisms: upperSet(config.Initialisms)}, config: config}
+	tests := []struct {
+		name, want string
+	}{
+		{"userId", "userID"},
+		{"newGrpcClient", "newGRPCClient"},
+		{"isOk", "isOk"},
+		{"HttpServer", "HttpServer"},
+	}
+	for _, test := range tests {
+		if got := f.fixName(test.name); got != test.want {
+			t.Errorf("lintName(%q) = %q, want %q", test.name, got, test.want)
+		}
+	}
+}
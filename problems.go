@@ -0,0 +1,82 @@
+package hint
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// FilterByConfidence returns the problems whose confidence is at least min,
+// in their original order. It uses the same threshold rule as
+// Config.MinConfidence, so linting once with a low threshold and filtering
+// afterwards gives the same result as linting with a higher one.
+func FilterByConfidence(problems []Problem, min float64) []Problem {
+	var res []Problem
+	for _, p := range problems {
+		if p.Confidence >= min {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// MaxConfidence returns the highest confidence among problems, or 0 if there are none.
+func MaxConfidence(problems []Problem) float64 {
+	max := 0.0
+	for _, p := range problems {
+		if p.Confidence > max {
+			max = p.Confidence
+		}
+	}
+	return max
+}
+
+// CountFixable returns the number of problems that come with a suggested fix.
+func CountFixable(problems []Problem) int {
+	n := 0
+	for i := range problems {
+		if problems[i].Fixable() {
+			n++
+		}
+	}
+	return n
+}
+
+// Summarize returns the number of problems in each category.
+func Summarize(problems []Problem) map[string]int {
+	counts := make(map[string]int)
+	for i := range problems {
+		counts[problems[i].Category]++
+	}
+	return counts
+}
+
+// CountBySeverity returns the number of problems of each severity
+// ("ignore", "info", "warning" or "error"), as derived from their confidence
+// the same way the checkstyle reporter does.
+func CountBySeverity(problems []Problem) map[string]int {
+	counts := make(map[string]int)
+	for i := range problems {
+		counts[confidenceToSeverity(problems[i].Confidence)]++
+	}
+	return counts
+}
+
+// FormatSummary renders counts as returned by Summarize or CountBySeverity,
+// e.g. "comments: 7, errors: 3, naming: 12", with keys in sorted order.
+func FormatSummary(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s: %d", k, counts[k])
+	}
+	return buf.String()
+}
@@ -0,0 +1,151 @@
+package hint
+
+import "testing"
+
+func TestFilterByConfidence(t *testing.T) {
+	problems := []Problem{
+		{Text: "a", Confidence: 0.2},
+		{Text: "b", Confidence: 0.8},
+		{Text: "c", Confidence: 0.5},
+		{Text: "d", Confidence: 1},
+	}
+	tests := []struct {
+		min  float64
+		want string
+	}{
+		{0, "abcd"},
+		{0.2, "abcd"},
+		{0.21, "bcd"},
+		{0.5, "bcd"},
+		{0.8, "bd"},
+		{1, "d"},
+		{1.1, ""},
+	}
+	for _, test := range tests {
+		got := ""
+		for _, p := range FilterByConfidence(problems, test.min) {
+			got += p.Text
+		}
+		if got != test.want {
+			t.Errorf("FilterByConfidence(%v) = %q, want %q", test.min, got, test.want)
+		}
+	}
+}
+
+func TestFilterByConfidenceMatchesMinConfidence(t *testing.T) {
+	src := []byte(`// Package foo ...
+package foo
+
+func f() {
+	var x int = 0
+	x += 1
+}
+`)
+	l := new(Linter)
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	all, err := l.Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	config.MinConfidence = 0.9
+	strict, err := l.Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	filtered := FilterByConfidence(all, 0.9)
+	if len(filtered) != len(strict) || len(strict) == 0 {
+		t.Fatalf("FilterByConfidence returned %d problems, linting with MinConfidence returned %d", len(filtered), len(strict))
+	}
+	for i := range strict {
+		if filtered[i].Text != strict[i].Text {
+			t.Errorf("problem %d = %q, want %q", i, filtered[i].Text, strict[i].Text)
+		}
+	}
+}
+
+func TestMaxConfidence(t *testing.T) {
+	if got := MaxConfidence(nil); got != 0 {
+		t.Errorf("MaxConfidence(nil) = %v, want 0", got)
+	}
+	problems := []Problem{{Confidence: 0.3}, {Confidence: 0.9}, {Confidence: 0.6}}
+	if got := MaxConfidence(problems); got != 0.9 {
+		t.Errorf("MaxConfidence = %v, want 0.9", got)
+	}
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	src := []byte(`// Package foo ...
+package foo
+
+func f(m map[int]int) {
+	var x int = 0
+	var y int = g()
+	x += 1
+	for k, _ := range m {
+	}
+	s := make([]int, 0)
+	if x > 0 {
+		return
+	} else {
+		y--
+	}
+}
+`)
+	config := NewDefaultConfig()
+	config.MinConfidence = 0
+	ps, err := new(Linter).Lint("foo.go", config, src)
+	if err != nil {
+		t.Fatalf("Lint: %v", err)
+	}
+	want := map[string]string{
+		"should drop = 0 from declaration of var x; it is the zero value":                              " = 0" + "->",
+		"should omit type int from declaration of var y; it will be inferred from the right-hand side": " int" + "->",
+		"should replace x += 1 with x++":                                                               "x += 1->x++",
+		"should omit 2nd value from range; this loop is equivalent to `for k := range ...`":            ", _->",
+		`can probably use "var s []int" instead`:                                                       "s := make([]int, 0)->var s []int",
+	}
+	for _, p := range ps {
+		w, ok := want[p.Text]
+		if !ok {
+			if p.Fixable() {
+				t.Errorf("problem %q should not be fixable", p.Text)
+			}
+			continue
+		}
+		delete(want, p.Text)
+		if !p.Fixable() {
+			t.Errorf("problem %q should be fixable", p.Text)
+			continue
+		}
+		fix := p.SuggestedFix
+		if got := string(src[fix.Pos.Offset:fix.End.Offset]) + "->" + fix.NewText; got != w {
+			t.Errorf("fix for %q = %q, want %q", p.Text, got, w)
+		}
+	}
+	for text := range want {
+		t.Errorf("problem %q not reported", text)
+	}
+	if got, want := CountFixable(ps), 5; got != want {
+		t.Errorf("CountFixable = %d, want %d", got, want)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	problems := []Problem{
+		{Category: "naming", Confidence: 0.8},
+		{Category: "errors", Confidence: 0.3},
+		{Category: "naming", Confidence: 1},
+		{Category: "comments", Confidence: 0.6},
+		{Category: "naming", Confidence: 0.1},
+	}
+	if got, want := FormatSummary(Summarize(problems)), "comments: 1, errors: 1, naming: 3"; got != want {
+		t.Errorf("Summarize = %q, want %q", got, want)
+	}
+	if got, want := FormatSummary(CountBySeverity(problems)), "error: 2, ignore: 1, info: 1, warning: 1"; got != want {
+		t.Errorf("CountBySeverity = %q, want %q", got, want)
+	}
+	if got := FormatSummary(Summarize(nil)); got != "" {
+		t.Errorf("FormatSummary of no problems = %q, want empty", got)
+	}
+}
@@ -101,7 +101,7 @@ func (r *checkstyleReporter) Flush() (result string, err error) {
 			message := checkstyleMessage{
 				Line:     p.Position.Line,
 				Column:   p.Position.Column,
-				Severity: r.confidenceToSeverity(p.Confidence),
+				Severity: confidenceToSeverity(p.Confidence),
 				Message:  p.Text,
 			}
 
@@ -128,7 +128,8 @@ func (r *checkstyleReporter) Flush() (result string, err error) {
 	return
 }
 
-func (r *checkstyleReporter) confidenceToSeverity(c float64) string {
+// confidenceToSeverity maps the confidence of a problem to a checkstyle severity level
+func confidenceToSeverity(c float64) string {
 	if c < .25 {
 		return checkstyleSeverityIgnore
 	} else if c < .5 {
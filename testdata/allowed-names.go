@@ -0,0 +1,18 @@
+// Test for names exempt from the underscore and initialism rules.
+// CONFIG {"allowed-underscore-names": {"Some_Name": true}, "allowed-name-regexps": ["^E[A-Z_]+$", "^Test_"]}
+
+// Package foo ...
+package foo
+
+// Some_Name is allowed by name.
+var Some_Name int
+
+// EACCES_X is allowed by a regexp.
+const EACCES_X = 13
+
+// Test_thing is allowed by a regexp.
+func Test_thing() {}
+
+func other_name(userId int) {} // MATCH /don't use underscores in Go names; func other_name should be otherName/
+
+// MATCH:16 /func parameter userId should be userID/
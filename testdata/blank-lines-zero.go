@@ -0,0 +1,13 @@
+// Test for blank lines in function bodies when none are allowed.
+// CONFIG {"blank-lines": true, "max-blank-lines": 0}
+
+// Package foo ...
+package foo
+
+func f() {
+	a := 1
+
+	_ = a
+}
+
+// MATCH:9 /use at most 0/
@@ -0,0 +1,31 @@
+// Test for multiple blank lines in function bodies.
+// CONFIG {"blank-lines": true}
+
+// Package foo ...
+package foo
+
+
+
+func f() {
+	a := 1
+
+	b := 2
+
+
+
+	c := `raw
+
+
+
+string`
+	/* block
+
+
+	comment */
+	_, _, _ = a, b, c
+
+
+}
+
+// MATCH:14 /too many consecutive blank lines in function body; use at most 1/
+// MATCH:27 /too many consecutive blank lines/
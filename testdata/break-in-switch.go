@@ -0,0 +1,44 @@
+// Test for breaks in switches in loops.
+// CONFIG {"break-in-switch": true}
+
+// Package foo ...
+package foo
+
+func f(xs []int, ch chan int) int {
+	n := 0
+	for _, x := range xs {
+		switch x {
+		case 0:
+			break // MATCH /break only exits the switch, not the enclosing loop; use a labeled break to end the loop/
+		case 1:
+			n++
+			// Stop the loop here.
+			break // MATCH /break only exits the switch/
+		case 2:
+			n++
+			break
+		default:
+			n += x
+		}
+	}
+	for {
+		select {
+		case x := <-ch:
+			n += x
+		default:
+			break // MATCH /break only exits the select/
+		}
+	}
+loop:
+	for {
+		switch n {
+		case 3:
+			break loop
+		}
+	}
+	switch n {
+	case 4:
+		break
+	}
+	return n
+}
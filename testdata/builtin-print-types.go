@@ -0,0 +1,12 @@
+// Test for calls to the builtin print and println functions with type information.
+// CONFIG {"builtin-print": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+func f() {
+	println("debug") // MATCH /call to builtin println; use fmt or log instead/
+	print("x")
+}
+
+func print(s string) {}
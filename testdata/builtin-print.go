@@ -0,0 +1,22 @@
+// Test for calls to the builtin print and println functions.
+// CONFIG {"builtin-print": true}
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+func f() {
+	println("debug") // MATCH /call to builtin println; use fmt or log instead/
+	print(1, 2)      // MATCH /call to builtin print; use fmt or log instead/
+	fmt.Println("ok")
+	fmt.Print("ok")
+	func() {
+		defer println("deferred") // MATCH /call to builtin println/
+	}()
+}
+
+func g() {
+	print := func(s string) {}
+	print("shadowed")
+}
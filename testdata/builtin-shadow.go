@@ -0,0 +1,29 @@
+// Test for declarations shadowing builtin identifiers.
+// CONFIG {"builtin-shadow": true}
+
+// Package foo ...
+package foo
+
+var len = 3 // MATCH /var len shadows the builtin function len/
+
+const iota = 1 // MATCH /const iota shadows the builtin constant iota/
+
+func f(string int) (error bool) { // MATCH /parameter string shadows the builtin type string/
+	copy := 1 // MATCH /var copy shadows the builtin function copy/
+	for new := range []int{} { // MATCH /range var new shadows the builtin function new/
+		_ = new
+	}
+	g := func(cap int) {} // MATCH /parameter cap shadows the builtin function cap/
+	_, _ = copy, g
+	var ok, x = true, 2
+	_, _ = ok, x
+	return
+}
+
+type list[any interface{}] []int // MATCH /type parameter any shadows the builtin type any/
+
+func (len list[T]) get() {} // MATCH /receiver len shadows the builtin function len/
+
+func first[max int](xs []max) {} // MATCH /type parameter max shadows the builtin function max/
+
+// MATCH:11 /result error shadows the builtin type error/
@@ -0,0 +1,15 @@
+// Test for per-category confidence thresholds.
+// CONFIG {"min-confidence": 0.5, "category-min-confidence": {"naming": 0.9}, "large-param-copy": true, "large-param-fields": 1}
+
+// Package foo ...
+package foo
+
+type pair struct {
+	a, b int
+}
+
+func sum(p pair) int { // MATCH /parameter p copies struct pair/
+	var my_sum int
+	my_sum = p.a + p.b
+	return my_sum
+}
@@ -0,0 +1,27 @@
+// Test for comments without a space after the slashes.
+// CONFIG {"comment-spacing": true}
+
+// Package foo ...
+package foo
+
+//go:generate stringer -type=T
+
+//T is a thing. MATCH /comment should have a space after \/\//
+type T int
+
+//export callback
+func callback() {}
+
+/* block comments are fine */
+
+//nolint:errcheck
+func f() {
+	//
+	/// triple slashes are fine
+	x := 1 //increment below MATCH /comment should have a space after \/\//
+	x++
+	//lint:ignore SA4006 reason
+	_ = x
+}
+
+//line foo.go:10
@@ -0,0 +1,19 @@
+// Test for results of concrete error types with type information.
+// CONFIG {"concrete-error-return": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+import "os"
+
+type myError struct{}
+
+func (e myError) Error() string { return "" }
+
+type notError struct{}
+
+func f() *myError { return nil } // MATCH /f should return error instead of \*myError/
+
+func h() *notError { return nil }
+
+func i() (*os.PathError, error) { return nil, nil } // MATCH /i should return error instead of \*os.PathError/
@@ -0,0 +1,25 @@
+// Test for results of concrete error types.
+// CONFIG {"concrete-error-return": true}
+
+// Package foo ...
+package foo
+
+import "os"
+
+type myError struct{}
+
+func (e *myError) Error() string { return "" }
+
+type notError struct{}
+
+func f() *myError { return nil } // MATCH /f should return error instead of \*myError; a nil \*myError returned as an error is not a nil error/
+
+func g() (int, *myError) { return 0, nil } // MATCH /g should return error/
+
+func h() *notError { return nil }
+
+func newMyError() *myError { return &myError{} }
+
+func i() (*os.PathError, error) { return nil, nil }
+
+func j() error { return nil }
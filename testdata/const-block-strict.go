@@ -0,0 +1,29 @@
+// Test for docs in const blocks when each constant needs its own.
+// CONFIG {"require-each-const-doc": true}
+
+// Package foo ...
+package foo
+
+// Color is a color.
+type Color int
+
+// Colors.
+const (
+	// Red is red.
+	Red Color = iota
+	Green
+	Blue
+	Black // the absence of color
+	white
+)
+
+const (
+	Alpha = "a"
+)
+
+// Single is a single constant.
+const Single = 1
+
+// MATCH:14 /exported const Green should have its own comment, not only one on its block, or be unexported/
+// MATCH:15 /exported const Blue should have its own comment/
+// MATCH:21 /exported const Alpha should have its own comment/
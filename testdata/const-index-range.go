@@ -0,0 +1,42 @@
+// Test for constant indexes out of the range of slice literals.
+// CONFIG {"const-index-range": true}
+
+// Package foo ...
+package foo
+
+func use(...interface{}) {}
+
+func f() {
+	s := []int{1, 2, 3}
+	use(s[0], s[2])
+	use(s[3]) // MATCH /index 3 out of range for s, a slice literal of length 3/
+	use(s[1:3], s[:0], s[3:])
+	use(s[2:5]) // MATCH /slice bound 5 out of range for s, a slice literal of length 3/
+
+	var keyed = []string{5: "a", "b"}
+	use(keyed[6])
+	use(keyed[0x7]) // MATCH /index 7 out of range for keyed, a slice literal of length 7/
+
+	arr := [3]int{1, 2, 3}
+	use(arr[1])
+
+	grown := []int{1}
+	grown = append(grown, 2)
+	use(grown[1])
+
+	shared := []int{1}
+	p := &shared
+	use(p, shared[1])
+
+	if true {
+		s := []int{}
+		use(s[1:1]) // MATCH /slice bound 1 out of range for s, a slice literal of length 0/
+	}
+	const k = 1
+	use(s[k])
+}
+
+func g(i int) {
+	s := []int{i: 1}
+	use(s[5])
+}
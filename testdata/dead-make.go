@@ -0,0 +1,51 @@
+// Test for make results that are overwritten before they are used.
+// CONFIG {"dead-make": true}
+
+// Package foo ...
+package foo
+
+func other() map[string]int { return nil }
+
+func f(n int) {
+	m := make(map[string]int) // MATCH /m is overwritten at line 11 before it is used, so this make is wasted/
+	m = other()
+	_ = m
+
+	var s = make([]int, n) // MATCH /s is overwritten at line 16/
+	println("unrelated")
+	s = nil
+	_ = s
+
+	a := make([]int, 0, n) // used before being overwritten
+	a = append(a, 1)
+	_ = a
+
+	b := make([]int, n) // OK, reassigned in terms of itself
+	b = b[:1]
+
+	c := make(chan int)
+	go func() { c <- 1 }()
+	c = nil
+
+	d := make([]int, n)
+	if n > 0 {
+		d = nil // only on one path
+	}
+	_ = d
+
+	for i := 0; i < n; i++ {
+		e := make([]byte, i) // MATCH /e is overwritten at line 38/
+		e = []byte("x")
+		_ = e
+	}
+
+	switch n {
+	case 1:
+		g := make([]int, 1) // MATCH /g is overwritten/
+		g, n = nil, 2
+		_ = g
+	}
+
+	h := make([]int, n)
+	h[0], h = 1, nil
+}
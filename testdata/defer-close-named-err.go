@@ -0,0 +1,50 @@
+// Test for deferred Close calls in functions with a named error result.
+// CONFIG {"defer-close-named-err": true, "min-confidence": 0.3}
+
+// Package foo ...
+package foo
+
+import "os"
+
+func read(name string) (n int, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close() // MATCH /error of deferred f.Close is lost; consider defer func\(\) { if cerr := f.Close\(\); cerr != nil && err == nil { err = cerr } }\(\)/
+	return 0, nil
+}
+
+func unnamed(name string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return 0, nil
+}
+
+func handled(name string) (rerr error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && rerr == nil {
+			rerr = cerr
+		}
+	}()
+	return nil
+}
+
+func nested() (err error) {
+	func() {
+		f, _ := os.Open("a")
+		defer f.Close()
+	}()
+	return func() (ferr error) {
+		f, _ := os.Open("b")
+		defer f.Close() // MATCH /error of deferred f.Close is lost; consider .* ferr == nil { ferr = cerr }/
+		return nil
+	}()
+}
@@ -0,0 +1,37 @@
+// Test for the form of deprecation notices.
+// CONFIG {"deprecated": true}
+
+// Package foo ...
+package foo
+
+// F is deprecated, use G.
+// MATCH /deprecation notice should be a paragraph of the form "Deprecated: ..."/
+func F() {}
+
+// G does something.
+//
+// Deprecated: use H.
+func G() {}
+
+// T is a thing.
+// DEPRECATED - do not use.
+// MATCH /deprecation notice/
+type T struct {
+	// Old is old.
+	// Do not use it.
+	// MATCH /deprecation notice/
+	Old int
+
+	// New is new.
+	//
+	// Deprecated: use Newer.
+	New int
+}
+
+// V is a value.
+// Deprecated: the notice is not a paragraph on its own.
+// MATCH /deprecation notice/
+var V = 1
+
+// H does something.
+func H() {}
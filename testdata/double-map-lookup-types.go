@@ -0,0 +1,16 @@
+// Test for map lookups repeated after a check, with type information.
+// CONFIG {"double-map-lookup": true, "min-confidence": 0.4, "type-check": true}
+
+// Package foo ...
+package foo
+
+func use(interface{}) {}
+
+func f(m map[string]*int, s []*int, k string) {
+	if m[k] != nil {
+		use(m[k]) // MATCH /m\[k\] is looked up again after the check at line 10/
+	}
+	if s[0] != nil {
+		use(s[0])
+	}
+}
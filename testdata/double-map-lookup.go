@@ -0,0 +1,27 @@
+// Test for map lookups repeated after a check.
+// CONFIG {"double-map-lookup": true, "min-confidence": 0.4}
+
+// Package foo ...
+package foo
+
+func use(interface{}) {}
+
+func f(m map[string]*int, counts map[string]int, k string) {
+	if m[k] != nil {
+		use(m[k]) // MATCH /m\[k\] is looked up again after the check at line 10; consider v, ok := m\[k\]/
+		use(m[k])
+	}
+	if m["a"] != nil && m["b"] != nil {
+		use(m["b"]) // MATCH /m\["b"\] is looked up again after the check at line 14/
+	}
+	if counts[k] > 0 {
+		counts[k] = 0
+		counts[k]++
+	}
+	if v, ok := m[k]; ok {
+		use(v)
+	}
+	if m[k] != nil {
+		use(m["other"])
+	}
+}
@@ -0,0 +1,33 @@
+// Test for duplicate cases in switch statements.
+// CONFIG {"duplicate-case": true}
+
+// Package foo ...
+package foo
+
+func f(x int, v interface{}) {
+	switch x {
+	case 1, 2:
+	case 3:
+	case 2: // MATCH /duplicate case 2 in switch; it is already handled at line 9/
+	case 4, 3: // MATCH /duplicate case 3 in switch; it is already handled at line 10/
+	default:
+	}
+
+	switch {
+	case x > 0:
+	case x < 0:
+	case x > 0: // MATCH /duplicate case x > 0 in switch/
+	}
+
+	switch v.(type) {
+	case int, string:
+	case nil:
+	case []byte, string: // MATCH /duplicate type case string in switch; it is already handled at line 23/
+	}
+
+	switch y := v.(type) {
+	case int:
+		_ = y
+	case error:
+	}
+}
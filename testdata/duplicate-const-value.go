@@ -0,0 +1,40 @@
+// Test for exported constants with the same value in a block.
+// CONFIG {"duplicate-const-value": true}
+
+// Package foo ...
+package foo
+
+// Limits.
+const (
+	MaxUsers  = 100
+	MaxGroups = 100 // MATCH /exported const MaxGroups has the same value 100 as MaxUsers; if they are meant to be equal, define MaxGroups as MaxUsers/
+	MaxItems  = 0x64 // MATCH /exported const MaxItems has the same value 0x64 as MaxUsers/
+	MaxRatio  = 100.0
+	maxHidden = 100
+)
+
+// Names.
+const (
+	NameA string = "a"
+	NameB        = "a"
+	NameC string = "a" // MATCH /exported const NameC has the same value "a" as NameA/
+)
+
+// Kind is a kind.
+type Kind int
+
+// Kinds.
+const (
+	KindA Kind = iota
+	KindB
+	KindC = 1
+)
+
+// Singles.
+const Single = 1
+
+// Others.
+const (
+	OtherA = Single
+	OtherB = Single
+)
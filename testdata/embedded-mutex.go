@@ -0,0 +1,27 @@
+// Test for exported structs embedding a mutex.
+// CONFIG {"embedded-mutex": true}
+
+// Package foo ...
+package foo
+
+import "sync"
+
+// Cache ...
+type Cache struct {
+	sync.Mutex // MATCH /exported type Cache embeds sync.Mutex, which exports its locking methods; use an unexported field like mu sync.Mutex instead/
+	m          map[string]string
+}
+
+// Store ...
+type Store struct {
+	*sync.RWMutex // MATCH /exported type Store embeds \*sync.RWMutex/
+}
+
+// Safe ...
+type Safe struct {
+	mu sync.Mutex
+}
+
+type cache struct {
+	sync.Mutex
+}
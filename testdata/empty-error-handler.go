@@ -0,0 +1,39 @@
+// Test for empty error handling blocks.
+// CONFIG {"empty-error-handler": true}
+
+// Package foo ...
+package foo
+
+import "os"
+
+func f() error {
+	err := os.Remove("a")
+	if err != nil { // MATCH /empty block for err != nil swallows the error/
+	}
+	if err := os.Remove("b"); err != nil { // MATCH /empty block for err != nil swallows the error/
+		// TODO: handle it
+	}
+	if nil != err { // MATCH /empty block for nil != err swallows the error/
+	}
+	var closeErr error
+	if closeErr != nil { // MATCH /empty block for closeErr != nil swallows the error/
+	} else {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err == nil {
+	}
+	if err != nil { //nolint: best effort cleanup
+	}
+	if err != nil {
+		//nolint
+	}
+	if err != nil {
+	} //nolint
+	n := 0
+	if n != 0 {
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Test for iota enums without a String method.
+// CONFIG {"enum-stringer": true}
+
+// Package foo ...
+package foo
+
+// Color is a color.
+type Color int
+
+// Colors.
+const (
+	Red Color = iota // MATCH /enum type Color has 3 exported constants but no String method; consider adding one or generating it with stringer -type=Color/
+	Green
+	Blue
+)
+
+// Size is a size.
+type Size int
+
+// Sizes.
+const (
+	Small Size = iota + 1
+	Medium
+	Large
+)
+
+func (s Size) String() string { return "" }
+
+// Kind is a kind.
+type Kind int
+
+// Kinds.
+const (
+	KindA Kind = iota
+	KindB
+	kindC
+)
+
+// Weekday is a day.
+type Weekday int
+
+// Days.
+const (
+	Monday    Weekday = 1
+	Tuesday   Weekday = 2
+	Wednesday Weekday = 3
+)
+
+// Mode is a mode.
+type Mode int
+
+// Modes, after an unexported zero value.
+const (
+	modeNone Mode = iota
+	ModeRead      // MATCH /enum type Mode has 3 exported constants but no String method/
+	ModeWrite
+	ModeAppend
+)
@@ -0,0 +1,42 @@
+// Test for returning error types other than error before the last result.
+// CONFIG {"error-type-names": ["os.PathError", "*net.OpError"]}
+
+// Package foo ...
+package foo
+
+import (
+	"net"
+	"os"
+)
+
+type myError struct{}
+
+func (e *myError) Error() string { return "my error" }
+
+// Check for an error type declared in the package.
+func a() (*myError, int) { // MATCH /error should be the last type/
+	return nil, 0
+}
+
+// Check for listed error types.
+func b() (*os.PathError, int) { // MATCH /error should be the last type/
+	return nil, 0
+}
+
+func c() (net.OpError, string) { // MATCH /error should be the last type/
+	return net.OpError{}, ""
+}
+
+// Check for types that aren't listed.
+func d() (*os.File, int) { // ok
+	return nil, 0
+}
+
+// Check for named results sharing a type.
+func e() (n int, err1, err2 error) { // MATCH /error should be the last type/
+	return 0, nil, nil
+}
+
+func g() (x, y int, err error) { // ok
+	return 0, 0, nil
+}
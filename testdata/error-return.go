@@ -41,3 +41,17 @@ func k() (int, error, int) { // MATCH /error should be the last type/
 func l() (x int, err error, y int) { // MATCH /error should be the last type/
 	return 0, nil, 0
 }
+
+// Check for error in the wrong location with grouped named variables.
+func m() (n int, err error, extra string) { // MATCH /error should be the last type/
+	return 0, nil, ""
+}
+
+// Check for positions counted across grouped names.
+func o() (a, b int, err error) { // ok
+	return 0, 0, nil
+}
+
+func p() (a, err error, b int) { // MATCH /error should be the last type/
+	return nil, nil, 0
+}
@@ -0,0 +1,20 @@
+// Test for errors formatted with verbs other than %v and %w.
+// CONFIG {"error-verb": true}
+
+// Package foo ...
+package foo
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+func f(name string, err error) error {
+	fmt.Printf("open %s: %s\n", name, err) // MATCH /error err is formatted with %s in fmt.Printf; use %v/
+	log.Printf("open %q: %v", name, err)
+	fmt.Fprintf(os.Stderr, "%d: %q\n", 1, err) // MATCH /error err is formatted with %q in fmt.Fprintf; use %v/
+	closeErr := os.ErrClosed
+	log.Fatalf("%s", closeErr) // MATCH /error closeErr is formatted with %s in log.Fatalf; use %v/
+	return fmt.Errorf("open %s: %s", name, err) // MATCH /error err is formatted with %s in fmt.Errorf; use %w/
+}
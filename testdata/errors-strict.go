@@ -0,0 +1,31 @@
+// Test for naming error variables not made with errors.New.
+// CONFIG {"error-var-naming-strict": true, "error-type-names": ["os.PathError"]}
+
+// Package foo ...
+package foo
+
+import (
+	"errors"
+	"os"
+)
+
+type myError struct{}
+
+func (*myError) Error() string { return "my error" }
+
+// NotFound ...
+var NotFound = &myError{} // MATCH /error var NotFound should have name of the form ErrFoo/
+
+// Missing ...
+var Missing, errClosed error = os.ErrNotExist, os.ErrClosed // MATCH /error var Missing should have name of the form ErrFoo/
+
+var (
+	badPath       = &os.PathError{Op: "open"} // MATCH /error var badPath should have name of the form errFoo/
+	errOK, second = errors.New("ok"), myError{} // MATCH /error var second should have name of the form errFoo/
+)
+
+var _ error = (*myError)(nil)
+
+var lastErr error
+
+var count = 0
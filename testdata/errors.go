@@ -13,6 +13,8 @@ var unexp = errors.New("some unexported error") // MATCH /error var.*unexp.*errF
 // Exp ...
 var Exp = errors.New("some exported error") // MATCH /error var.*Exp.*ErrFoo/
 
+var _ = errors.New("blank") // MATCH /error var _ should have name of the form errFoo/
+
 var (
 	e1 = fmt.Errorf("blah %d", 4) // MATCH /error var.*e1.*errFoo/
 	// E2 ...
@@ -0,0 +1,18 @@
+// Test for exec.Command with arguments in the program name.
+// CONFIG {"exec-command": true}
+
+// Package foo ...
+package foo
+
+import (
+	"context"
+	"os/exec"
+)
+
+func f(ctx context.Context) {
+	exec.Command("ls -l")                  // MATCH /exec.Command runs "ls -l" as the program name; pass the arguments separately/
+	exec.CommandContext(ctx, "git status") // MATCH /exec.CommandContext runs "git status" as the program name/
+	exec.Command("ls", "-l")
+	exec.Command("/usr/bin/my tool")       // MATCH /exec.Command runs/
+	exec.CommandContext(ctx, "ls", "-l a")
+}
@@ -0,0 +1,51 @@
+// Test for unexported sentinel errors returned by exported functions.
+// CONFIG {"exported-sentinels": true}
+
+// Package foo ...
+package foo
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNotFound = errors.New("not found")
+
+var errBadInput = fmt.Errorf("bad input")
+
+// ErrClosed is returned when closed.
+var ErrClosed = errors.New("closed")
+
+// Find finds things.
+func Find(k string) error {
+	if k == "" {
+		return errBadInput // MATCH /exported Find returns unexported error errBadInput; callers can't check for it with errors.Is/
+	}
+	if k == "closed" {
+		return ErrClosed
+	}
+	f := func() error { return errNotFound }
+	_ = f
+	return errNotFound // MATCH /exported Find returns unexported error errNotFound/
+}
+
+func find() error {
+	return errNotFound
+}
+
+// Shadow shadows the sentinel.
+func Shadow() error {
+	errNotFound := errors.New("local")
+	return errNotFound
+}
+
+// Open opens things.
+func Open(name string) error {
+	if name == "" {
+		return fmt.Errorf("open %q: %w", name, errBadInput)
+	}
+	if name == "closed" {
+		return fmt.Errorf("open %q: %w", name, ErrClosed)
+	}
+	return fmt.Errorf("open %q: %v", name, errNotFound)
+}
@@ -0,0 +1,16 @@
+// Test for world-writable file modes.
+// CONFIG {"file-permissions": true}
+
+// Package foo ...
+package foo
+
+import "os"
+
+func f(name string, data []byte) {
+	os.OpenFile(name, os.O_CREATE, 0777) // MATCH /os.OpenFile with mode 0777 is world-writable \(others: rwx\)/
+	os.Mkdir(name, 0o776)                // MATCH /os.Mkdir with mode 0o776 is world-writable \(others: rw-\)/
+	os.WriteFile(name, data, 0666)       // MATCH /os.WriteFile with mode 0666 is world-writable \(others: rw-\)/
+	os.MkdirAll(name, 0755)
+	os.WriteFile(name, data, 0644)
+	os.OpenFile(name, os.O_RDONLY, 0)
+}
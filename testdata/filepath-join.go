@@ -0,0 +1,14 @@
+// Test for suspicious filepath.Join elements.
+// CONFIG {"filepath-join": true}
+
+// Package foo ...
+package foo
+
+import "path/filepath"
+
+func f(base, name string) {
+	use(filepath.Join(base, "/etc/passwd"))    // MATCH /suspicious element "\/etc\/passwd" in filepath.Join/
+	use(filepath.Join(base, "data", "../..")) // MATCH /suspicious element "..\/.." in filepath.Join/
+	use(filepath.Join("/var/lib", name))
+	use(filepath.Join(base, "data", name))
+}
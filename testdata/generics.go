@@ -0,0 +1,49 @@
+// Test for generic functions and types.
+
+// Package foo ...
+package foo
+
+// Map applies fn to each element.
+func Map[T, U any](s []T, fn func(T) U) []U {
+	var r []U
+	for _, v := range s {
+		r = append(r, fn(v))
+	}
+	return r
+}
+
+// Set is a set.
+type Set[K comparable] map[K]struct{}
+
+// Tree is a tree.
+type Tree[Elem any, Key_Type comparable] struct { // MATCH /don't use underscores in Go names; type parameter Key_Type should be KeyType/
+	root *node[Elem]
+}
+
+type node[E any] struct{}
+
+// Keys returns the keys.
+func Keys[Map_K comparable, V any](m map[Map_K]V) []Map_K { // MATCH /don't use underscores in Go names; func type parameter Map_K should be MapK/
+	return nil
+}
+
+// Has reports whether s has k.
+func (s Set[K]) Has(k K) bool {
+	_, ok := s[k]
+	return ok
+}
+
+// Lookup looks up an element by ID.
+func Lookup[Id comparable, E any](m map[Id]E, id Id) E { // MATCH /func type parameter Id should be ID/
+	return m[id]
+}
+
+// Len returns the length of s.
+func Len[S ~[]E, E any](s S) int {
+	return len(s)
+}
+
+func Exported[T any]() { // MATCH /exported function Exported should have comment/
+}
+
+type Thing[T any] struct{} // MATCH /exported type Thing should have comment or be unexported/
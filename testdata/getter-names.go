@@ -0,0 +1,25 @@
+// Test for getters with a Get prefix.
+// CONFIG {"getter-names": true}
+
+// Package foo ...
+package foo
+
+// T is a thing.
+type T struct{ owner string }
+
+// GetOwner returns the owner.
+func (t *T) GetOwner() string { return t.owner } // MATCH /method GetOwner should be Owner; getters don't use a Get prefix/
+
+// Owner returns the owner.
+func (t *T) Owner() string { return t.owner }
+
+// Get returns the thing.
+func (t *T) Get() *T { return t }
+
+// GetT returns a new T.
+func GetT() *T { return nil } // MATCH /func GetT should be T/
+
+// Getwd returns the working directory.
+func Getwd() string { return "" }
+
+func getThing() *T { return nil }
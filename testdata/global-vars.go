@@ -0,0 +1,32 @@
+// Test for exported package-level collections.
+// CONFIG {"no-global-vars": true, "min-confidence": 0.4}
+
+// Package foo ...
+package foo
+
+// Codes maps names to codes.
+var Codes = map[string]int{"a": 1} // MATCH /exported global Codes is a mutable map that any importer can modify; consider a function returning a copy/
+
+// Names are names.
+var Names = []string{"a", "b"} // MATCH /exported global Names is a mutable slice/
+
+var names = []string{"a"}
+
+// Grid is an array, which is copied on assignment.
+var Grid = [2]int{1, 2}
+
+// Point is a struct.
+var Point = struct{ X, Y int }{1, 2}
+
+// Exported variables.
+var (
+	// B is a slice.
+	B = []int{1} // MATCH /exported global B is a mutable slice/
+	// C isn't initialized with a literal.
+	C map[string]bool
+)
+
+func f() {
+	var Local = map[string]int{}
+	_ = Local
+}
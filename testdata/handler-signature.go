@@ -0,0 +1,40 @@
+// Test for HTTP handlers with the wrong signature.
+// CONFIG {"handler-signature": true}
+
+// Package foo ...
+package foo
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func register(r *mux.Router) {
+	http.HandleFunc("/", index)
+	http.HandleFunc("/ok", ok)
+	r.HandleFunc("/users", users)
+	http.HandleFunc("/inline", func(w http.ResponseWriter, req *http.Request) {})
+}
+
+func index(w http.ResponseWriter, r http.Request) { // MATCH /index looks like an HTTP handler but its signature is not func\(http.ResponseWriter, \*http.Request\)/
+}
+
+func ok(w http.ResponseWriter, r *http.Request) {}
+
+func users(r *http.Request, w http.ResponseWriter) { // MATCH /users looks like an HTTP handler/
+}
+
+func loginHandler(w http.ResponseWriter) { // MATCH /loginHandler looks like an HTTP handler/
+}
+
+func logoutHandler(w, _ http.ResponseWriter) { // MATCH /logoutHandler looks like an HTTP handler/
+}
+
+func statusHandler(w http.ResponseWriter, r *http.Request) {}
+
+func newHandler(prefix string) http.Handler {
+	return nil
+}
+
+func eventHandler(name string) {}
@@ -0,0 +1,21 @@
+// Test for hardcoded credentials.
+// CONFIG {"hardcoded-secret": true}
+
+// Package foo ...
+package foo
+
+const apiKey = "d41d8cd98f00b204" // MATCH /apiKey looks like a hardcoded secret/
+
+var (
+	dbPassword = "hunter2" // MATCH /dbPassword looks like a hardcoded secret/
+	adminUser  = "root"
+	authToken  = ""
+	secretHint = "changeme"
+)
+
+func f() {
+	Secret := "s3cr3t" // MATCH /Secret looks like a hardcoded secret/
+	token := readToken()
+	password := `p4ss` // MATCH /password looks like a hardcoded secret/
+	_, _, _ = Secret, token, password
+}
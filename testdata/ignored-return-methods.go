@@ -0,0 +1,60 @@
+// Test that return values of method calls are processed
+
+// Package pkg does something.
+package pkg
+
+import "errors"
+
+type conn struct{}
+
+func (c *conn) Close() error {
+	return errors.New("err")
+}
+
+func (c conn) Read() (int, error) {
+	return 0, nil
+}
+
+func (c conn) Len() int {
+	return 0
+}
+
+func (c conn) Reset() {
+}
+
+func (c *conn) closeAll() {
+	c.Close() // MATCH /function 'c.Close' returns an error, it should not be silently ignored/
+}
+
+type stack[T any] struct{}
+
+func (s *stack[T]) Pop() (T, error) {
+	var v T
+	return v, nil
+}
+
+func use(c *conn, s *stack[int]) {
+	c.Close()        // MATCH /function 'c.Close' returns an error, it should not be silently ignored/
+	n, _ := c.Read() // MATCH /function 'c.Read' returns an error, generally it should not be intentionally ignored/
+	_ = n
+	c.Len() // MATCH /result of 'c.Len' should not be silently ignored/
+	c.Reset()
+	s.Pop() // MATCH /function 's.Pop' returns an error, it should not be silently ignored/
+
+	lit := conn{}
+	lit.Close() // MATCH /function 'lit.Close' returns an error, it should not be silently ignored/
+	ptr := &conn{}
+	ptr.Close() // MATCH /function 'ptr.Close' returns an error, it should not be silently ignored/
+	var v conn
+	v.Close() // MATCH /function 'v.Close' returns an error, it should not be silently ignored/
+	var w = conn{}
+	w.Close() // MATCH /function 'w.Close' returns an error, it should not be silently ignored/
+
+	// The type of a call result isn't known without type information.
+	other := newConn()
+	other.Close()
+}
+
+func newConn() *conn {
+	return &conn{}
+}
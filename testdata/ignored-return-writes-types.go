@@ -0,0 +1,35 @@
+// Test for ignored errors of writes to an io.Writer with type information.
+// CONFIG {"type-check": true}
+
+// Package pkg does something.
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) int { return len(p) }
+
+func write(w io.Writer, bw *bufio.Writer, cw *csv.Writer, nw nopWriter) {
+	w.Write(nil) // MATCH /function 'w.Write' returns an error, it should not be silently ignored/
+	bw.Flush()   // MATCH /function 'bw.Flush' returns an error, it should not be silently ignored/
+	cw.Flush()
+	nw.Write(nil) // MATCH /result of 'nw.Write' should not be silently ignored/
+
+	var buf bytes.Buffer
+	buf.Write(nil)
+	fmt.Fprintf(&buf, "a")
+	var sb strings.Builder
+	sb.Write(nil)
+	h := sha256.New()
+	h.Write(nil)
+	fmt.Fprintf(bw, "a") // MATCH /function 'fmt.Fprintf' returns an error, it should not be silently ignored/
+}
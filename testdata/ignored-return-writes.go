@@ -0,0 +1,28 @@
+// Test for ignored errors of writes to an io.Writer.
+
+// Package pkg does something.
+package pkg
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+func write(w io.Writer, bw *bufio.Writer) {
+	fmt.Fprintf(w, "%d\n", 1) // MATCH /function 'fmt.Fprintf' returns an error, it should not be silently ignored/
+	fmt.Fprintln(w, "a")      // MATCH /function 'fmt.Fprintln' returns an error, it should not be silently ignored/
+	fmt.Fprint(w)             // MATCH /function 'fmt.Fprint' returns an error, it should not be silently ignored/
+	fmt.Fprintln(os.Stderr, "a")
+	fmt.Fprintf(os.Stdout, "a")
+	fmt.Println("a")
+	_, _ = fmt.Fprintf(w, "a")
+	if _, err := fmt.Fprintf(w, "a"); err != nil {
+		return
+	}
+
+	// Without type information methods aren't matched.
+	w.Write(nil)
+	bw.Flush()
+}
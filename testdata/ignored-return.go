@@ -28,6 +28,10 @@ func returnErrTwo() (asd int, err error) {
 	return 0, errors.New("err")
 }
 
+func returnErrGrouped() (a, b int, err error) {
+	return 0, 0, nil
+}
+
 // TODO: implement deep check for returned values that implement error
 func returnErrStruct() (string, megaErr) {
 	return "", megaErr{}
@@ -45,6 +49,9 @@ func main() {
 		doSomethingMan()
 	}
 
+	x, y, _ := returnErrGrouped() // MATCH /function 'returnErrGrouped' returns an error, generally it should not be intentionally ignored/
+	_, _, err := returnErrGrouped()
+
 	//returnErrThree() // TODO: see above
 
 }
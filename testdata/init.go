@@ -0,0 +1,37 @@
+// Test for init functions.
+// CONFIG {"init": true, "max-init-statements": 2}
+
+// Package foo ...
+package foo
+
+var registry = map[string]int{}
+
+func register(name string, v int) {
+	registry[name] = v
+}
+
+func init() {
+	register("a", 1)
+}
+
+func init() { // MATCH /init function has 3 statements, more than 2; consider explicit initialization/
+	registry["b"] = 2
+	registry["c"] = 3
+	registry["d"] = 4
+}
+
+func init() {
+	register("e", 5)
+}
+
+func init() { // MATCH /more than one init function in file, the first at line 17; consider explicit initialization/
+	registry["f"] = 6
+}
+
+type t struct{}
+
+func (t) init() {
+	_ = 1
+	_ = 2
+	_ = 3
+}
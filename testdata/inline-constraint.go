@@ -0,0 +1,49 @@
+// Test for repeated inline union constraints.
+// CONFIG {"inline-constraint": true, "min-confidence": 0.2}
+
+// Package foo ...
+package foo
+
+// Number is a number.
+type Number interface {
+	~int | ~float64
+}
+
+// Sum sums.
+func Sum[T ~int | ~float64](s []T) T {
+	var r T
+	return r
+}
+
+// Max returns the maximum.
+func Max[T ~int | ~float64](a, b T) T { // MATCH /constraint ~int | ~float64 is repeated from line 13; consider a named interface/
+	return a
+}
+
+// Min returns the minimum.
+func Min[T interface{ ~int | ~string }](a, b T) T {
+	return a
+}
+
+// Vec is a vector.
+type Vec[T interface{ ~int | ~string }] []T // MATCH /constraint interface{ ~int | ~string } is repeated from line 24/
+
+// Abs returns the absolute value.
+func Abs[T Number](a T) T {
+	return a
+}
+
+// Neg negates.
+func Neg[T Number](a T) T {
+	return a
+}
+
+// Keys returns the keys.
+func Keys[K comparable, V any](m map[K]V) []K {
+	return nil
+}
+
+// Values returns the values.
+func Values[K comparable, V any](m map[K]V) []V {
+	return nil
+}
@@ -0,0 +1,21 @@
+// Test for math/rand used in security-sensitive functions.
+// CONFIG {"insecure-rand": true}
+
+// Package foo ...
+package foo
+
+import "math/rand"
+
+func newSessionToken() int64 {
+	return rand.Int63() // MATCH /math\/rand \(rand.Int63\) used in security-sensitive function newSessionToken.*crypto\/rand/
+}
+
+func makeSalt(b []byte) {
+	for i := range b {
+		b[i] = byte(rand.Intn(256)) // MATCH /math\/rand \(rand.Intn\) used in security-sensitive function makeSalt/
+	}
+}
+
+func shuffle(xs []int) {
+	rand.Shuffle(len(xs), func(i, j int) { xs[i], xs[j] = xs[j], xs[i] })
+}
@@ -0,0 +1,17 @@
+// Test for disabled TLS certificate verification.
+// CONFIG {"insecure-tls": true}
+
+// Package foo ...
+package foo
+
+import "crypto/tls"
+
+var insecure = &tls.Config{
+	InsecureSkipVerify: true, // MATCH /InsecureSkipVerify disables TLS certificate verification/
+}
+
+var secure = &tls.Config{InsecureSkipVerify: false, ServerName: "example.com"}
+
+func f() {
+	use(tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}) // MATCH /InsecureSkipVerify disables/
+}
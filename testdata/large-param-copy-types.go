@@ -0,0 +1,33 @@
+// Test for large structs passed by value, with type information.
+// CONFIG {"large-param-copy": true, "large-param-fields": 3, "type-check": true, "min-confidence": 0.5}
+
+// Package foo ...
+package foo
+
+import (
+	"archive/tar"
+	"time"
+)
+
+type big struct {
+	a, b, c int
+	d       string
+}
+
+type alias = big
+
+type named big
+
+func f1(h tar.Header) { // MATCH /parameter h copies struct tar.Header with \d+ fields on each call; consider passing \*tar.Header/
+}
+
+func f2(a alias, n named) { // MATCH /parameter a copies struct alias with 4 fields/
+}
+
+func f3(t time.Time, h *tar.Header, s []big) {
+}
+
+func (n named) method() { // MATCH /receiver n copies struct named with 4 fields/
+}
+
+// MATCH:24 /parameter n copies struct named with 4 fields/
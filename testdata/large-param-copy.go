@@ -0,0 +1,50 @@
+// Test for large structs passed by value.
+// CONFIG {"large-param-copy": true, "large-param-fields": 3, "min-confidence": 0.5}
+
+// Package foo ...
+package foo
+
+import "time"
+
+type small struct {
+	a, b int
+	c    string
+}
+
+type big struct {
+	a, b, c int
+	d       string
+}
+
+type embeds struct {
+	small
+	time.Time
+	x, y int
+}
+
+func f1(s small) {
+}
+
+func f2(b big) { // MATCH /parameter b copies struct big with 4 fields on each call; consider passing \*big/
+}
+
+func f3(b *big, bs []big) {
+}
+
+func f4(n int, x, y embeds) { // MATCH /parameter x copies struct embeds with 4 fields/
+}
+
+func f5(big) { // MATCH /parameter copies struct big with 4 fields/
+}
+
+func f6(t time.Time) {
+}
+
+func (b big) method() { // MATCH /receiver b copies struct big with 4 fields/
+}
+
+func (b *big) pointerMethod() {
+	_ = func(x big) {} // MATCH /parameter x copies struct big with 4 fields/
+}
+
+// MATCH:34 /parameter y copies struct embeds with 4 fields/
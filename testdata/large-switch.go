@@ -0,0 +1,83 @@
+// Test for switches that could be map lookups.
+// CONFIG {"max-switch-cases": 3}
+
+// Package foo ...
+package foo
+
+const (
+	red = iota
+	green
+)
+
+func name(c int) string {
+	switch c { // MATCH /switch has 4 cases that only map a value to another; consider a map lookup/
+	case red:
+		return "red"
+	case green:
+		return "green"
+	case 2, 3:
+		return "blue"
+	case -1:
+		return "none"
+	default:
+		return ""
+	}
+}
+
+func code(s string) (n int) {
+	switch s { // MATCH /switch has 4 cases/
+	case "a":
+		n = 1
+	case "b":
+		n = 2
+	case "c":
+		n = 3
+	case "d":
+		n = 4
+	}
+	return n
+}
+
+func mixed(s string) (n, m int) {
+	switch s {
+	case "a":
+		n = 1
+	case "b":
+		m = 2
+	case "c":
+		n = 3
+	case "d":
+		n = 4
+	}
+	switch s {
+	case "a":
+		return 1, 0
+	case "b", "c":
+		return 2, 0
+	case "d":
+		return 3, 0
+	case "e":
+		return len(s), 0
+	}
+	switch {
+	case s == "a":
+		n = 1
+	case s == "b":
+		n = 2
+	case s == "c":
+		n = 3
+	case s == "d":
+		n = 4
+	}
+	return n, m
+}
+
+func small(c int) string {
+	switch c {
+	case 1:
+		return "one"
+	case 2:
+		return "two"
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+// Test for comparisons of lengths that are always true or false.
+// CONFIG {"len-check": true}
+
+// Package foo ...
+package foo
+
+func f(s []int, m map[int]int) bool {
+	if len(s) < 0 { // MATCH /len\(s\) < 0 is always false; the result of len is never negative/
+		return false
+	}
+	if cap(s) >= 0 { // MATCH /cap\(s\) >= 0 is always true; the result of cap is never negative/
+		return true
+	}
+	if 0 > len(m) { // MATCH /0 > len\(m\) is always false/
+		return false
+	}
+	if len(s) == -1 { // MATCH /len\(s\) == -1 is always false/
+		return false
+	}
+	if len(s) > -1 { // MATCH /len\(s\) > -1 is always true/
+		return true
+	}
+	return len(s) > 0 || len(s) <= 0 || len(s) != 0 || len(s) < 1
+}
+
+func g(len func([]int) int, s []int) bool {
+	return len(s) < 0
+}
@@ -0,0 +1,16 @@
+// Test that loop variable capture is not flagged with Go 1.22 semantics.
+// CONFIG {"loop-var-capture": true, "go-version": "1.22"}
+// OK
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+func f(xs []int) {
+	for _, x := range xs {
+		go func() {
+			fmt.Println(x)
+		}()
+	}
+}
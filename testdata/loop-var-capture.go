@@ -0,0 +1,38 @@
+// Test for loop variables captured by go and defer statements.
+// CONFIG {"loop-var-capture": true}
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+func f(xs []int) {
+	for i, x := range xs {
+		go func() {
+			fmt.Println(x) // MATCH /loop variable x captured by func literal in go statement/
+		}()
+		defer func() {
+			fmt.Println(i) // MATCH /loop variable i captured by func literal in defer statement/
+		}()
+		go func(x int) {
+			fmt.Println(x)
+		}(x)
+		x := x
+		go func() {
+			fmt.Println(x)
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		go func() {
+			fmt.Println(i) // MATCH /loop variable i captured/
+		}()
+		func() {
+			fmt.Println(i)
+		}()
+	}
+
+	for _, x := range xs {
+		go fmt.Println(x)
+	}
+}
@@ -0,0 +1,12 @@
+// Test for magic numbers in durations.
+// CONFIG {"magic-numbers": true, "magic-numbers-durations": true, "allowed-magic-numbers": ["64"], "min-confidence": 0.4}
+
+// Package foo ...
+package foo
+
+import "time"
+
+func f() {
+	time.Sleep(5 * time.Second) // MATCH /magic number 5/
+	_ = 0x40
+}
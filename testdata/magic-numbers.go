@@ -0,0 +1,35 @@
+// Test for magic numbers.
+// CONFIG {"magic-numbers": true, "allowed-magic-numbers": ["100", "0.5"], "min-confidence": 0.4}
+
+// Package foo ...
+package foo
+
+import "time"
+
+const maxRetries = 3
+
+var buf [64]byte
+
+func f(n int) float64 {
+	if n > 42 { // MATCH /magic number 42; consider naming it with a constant/
+		return 0.5
+	}
+	for i := 0; i < maxRetries; i++ {
+		n = n*2 + 1
+	}
+	if n == -1 || n == -7 { // MATCH /magic number -7/
+		n = 100
+	}
+	time.Sleep(5 * time.Second)
+	_ = time.Duration(30) * time.Millisecond
+	x := []int{3} // MATCH /magic number 3/
+	_ = x
+	_ = [4]int{}
+	return 3.14 // MATCH /magic number 3.14/
+}
+
+func g() {
+	const local = 12
+	var y = 0x40 // MATCH /magic number 0x40/
+	_, _ = y, local
+}
@@ -0,0 +1,50 @@
+// Test for ranges over maps where the order may matter.
+// CONFIG {"map-range-order": true, "min-confidence": 0.4}
+
+// Package foo ...
+package foo
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+func f(m map[string]int, w io.Writer) []string {
+	var keys []string
+	for k := range m { // MATCH /range over map m, whose order is random, appends to keys; sort the keys first if the order matters/
+		keys = append(keys, k)
+	}
+
+	var sortedKeys []string
+	for k := range m {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	for k, v := range m { // MATCH /range over map m, whose order is random, writes output with fmt.Fprintf/
+		_, _ = fmt.Fprintf(w, "%s=%d\n", k, v)
+	}
+
+	counts := make(map[string]int)
+	for k, v := range counts { // MATCH /writes output with fmt.Println/
+		fmt.Println(k, v)
+	}
+
+	total := 0
+	for _, v := range m {
+		total += v
+	}
+
+	for k := range m {
+		var parts []string
+		parts = append(parts, k)
+		_ = parts
+	}
+
+	s := []string{"a"}
+	for _, v := range s {
+		keys = append(keys, v)
+	}
+	return keys
+}
@@ -0,0 +1,26 @@
+// Test for appends to slice fields of map values.
+// CONFIG {"map-struct-append": true}
+
+// Package foo ...
+package foo
+
+type group struct {
+	items []string
+}
+
+func add(groups map[string]group, k, x string) []string {
+	items := append(groups[k].items, x) // MATCH /append to field items of the value of map groups at key k has no effect on the map unless the value is stored back with groups\[k\] = v/
+	if k != "" {
+		items = append(groups[x].items, k) // MATCH /field items of the value of map groups at key x/
+	}
+	return items
+}
+
+func store(groups map[string]group, ptrs map[string]*group, k, x string) {
+	more := append(groups[k].items, x)
+	g := groups[k]
+	g.items = more
+	groups[k] = g
+
+	ptrs[k].items = append(ptrs[k].items, x)
+}
@@ -0,0 +1,18 @@
+// Test for output without a trailing newline in main packages.
+// CONFIG {"missing-newline": true}
+
+// Package main ...
+package main
+
+import "fmt"
+
+func main() {
+	fmt.Printf("done: %d", 3) // MATCH /output of fmt.Printf doesn't end with a newline; consider adding \\n or using fmt.Println/
+	fmt.Print("a", "b")       // MATCH /output of fmt.Print doesn't end with a newline/
+	fmt.Printf("done: %d\n", 3)
+	fmt.Print("name: ")
+	fmt.Print("50%\r")
+	fmt.Println("ok")
+	s := "x"
+	fmt.Print(s)
+}
@@ -0,0 +1,14 @@
+// Test for nested append calls.
+// CONFIG {"nested-append": true, "min-confidence": 0.2}
+
+// Package foo ...
+package foo
+
+func f(s []int, t []int) []int {
+	s = append(s, 1, 2)
+	s = append(s, t...)
+	s = append(append(s, 1), 2)             // MATCH /append nested 2 deep; consider a single variadic append or separate statements/
+	s = append(append(append(s, 1), 2), 3) // MATCH /append nested 3 deep/
+	s = append(s, append(t, 1)...)
+	return s
+}
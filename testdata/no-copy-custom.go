@@ -0,0 +1,18 @@
+// Test for copies of values that must not be copied, with custom types.
+// CONFIG {"no-copy": true, "no-copy-types": ["bytes.Buffer"]}
+
+// Package foo ...
+package foo
+
+import (
+	"bytes"
+	"sync"
+)
+
+func write(buf bytes.Buffer) { // MATCH /parameter buf copies a bytes.Buffer, which must not be copied; use \*bytes.Buffer/
+	buf.WriteString("x")
+}
+
+func lock(mu sync.Mutex) {
+	mu.Lock()
+}
@@ -0,0 +1,20 @@
+// Test for copies of values that must not be copied, with type information.
+// CONFIG {"no-copy": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+import "sync"
+
+type pool struct {
+	wgs []sync.WaitGroup
+}
+
+func wait(p *pool) {
+	for _, wg := range p.wgs { // MATCH /range variable wg copies a sync.WaitGroup/
+		wg.Wait()
+	}
+	for i := range p.wgs {
+		p.wgs[i].Wait()
+	}
+}
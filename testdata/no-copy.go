@@ -0,0 +1,54 @@
+// Test for copies of values that must not be copied.
+// CONFIG {"no-copy": true}
+
+// Package foo ...
+package foo
+
+import (
+	"sync"
+	at "sync/atomic"
+)
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+type registry struct {
+	c counter
+}
+
+type safe struct {
+	mu *sync.Mutex
+}
+
+func wait(wg sync.WaitGroup) { // MATCH /parameter wg copies a sync.WaitGroup, which must not be copied; use \*sync.WaitGroup/
+	wg.Wait()
+}
+
+func load(v at.Value) interface{} { // MATCH /parameter v copies a at.Value/
+	return v.Load()
+}
+
+func (c counter) get() int { // MATCH /receiver c copies a counter, which contains a sync.Mutex, which must not be copied; use \*counter/
+	return c.n
+}
+
+func newRegistry() registry { // MATCH /result copies a registry, which contains a sync.Mutex/
+	return registry{}
+}
+
+func ok(wg *sync.WaitGroup, s safe, c *counter) {
+	f := func(o sync.Once) {} // MATCH /parameter o copies a sync.Once/
+	_ = f
+}
+
+func sum(cs []counter) (n int) {
+	for _, c := range cs { // MATCH /range variable c copies a counter, which contains a sync.Mutex, which must not be copied; range over the indexes instead/
+		n += c.n
+	}
+	for i := range cs {
+		n += cs[i].n
+	}
+	return n
+}
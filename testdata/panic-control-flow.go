@@ -0,0 +1,50 @@
+// Test for panics used as control flow.
+// CONFIG {"panic-control-flow": true}
+
+// Package foo ...
+package foo
+
+import "errors"
+
+type bailout struct{ err error }
+
+type parseError string
+
+type stop struct{}
+
+type parser struct{}
+
+func (p *parser) fail(msg string) {
+	panic(bailout{errors.New(msg)}) // MATCH /panic with a bailout value recovered elsewhere in this file uses panics for control flow; return an error instead/
+}
+
+func (p *parser) failf(msg string) {
+	panic(parseError(msg)) // MATCH /panic with a parseError value/
+}
+
+func (p *parser) halt() {
+	s := &stop{}
+	panic(s) // MATCH /panic with a \*stop value/
+}
+
+func (p *parser) broken() {
+	panic("unreachable")
+}
+
+func (p *parser) parse() (err error) {
+	defer func() {
+		r := recover()
+		if b, ok := r.(bailout); ok {
+			err = b.err
+			return
+		}
+		switch e := recover().(type) {
+		case parseError:
+			err = errors.New(string(e))
+		case *stop:
+		case nil:
+		}
+	}()
+	p.fail("x")
+	return nil
+}
@@ -0,0 +1,14 @@
+// Test for parameters named after their type.
+// CONFIG {"param-name-equals-type": true}
+
+// Package foo ...
+package foo
+
+func f(str string, flag bool, num int, count int) { // MATCH /parameter str only repeats its type string; use a descriptive name or just s/
+	_ = func(Boolean bool) {} // MATCH /parameter Boolean only repeats its type bool/
+}
+
+func g(float float64, num float64, name string, s string) {} // MATCH /parameter float only repeats its type float64/
+
+// MATCH:7 /parameter flag only repeats its type bool/
+// MATCH:7 /parameter num only repeats its type int/
@@ -0,0 +1,35 @@
+// Test for functions named like questions that don't return a bool.
+// CONFIG {"predicate-naming": true}
+
+// Package foo ...
+package foo
+
+// IsValid ...
+func IsValid(s string) error { // MATCH /func IsValid reads as a question \(Is...\) but doesn't return a bool; rename it or return a bool/
+	return nil
+}
+
+func hasKey(m map[string]int, k string) int { // MATCH /func hasKey reads as a question \(has...\)/
+	return m[k]
+}
+
+type t struct{}
+
+func (t) CanRetry() { // MATCH /method CanRetry reads as a question \(Can...\)/
+}
+
+// Exists ...
+func Exists() (bool, error) { return false, nil }
+
+// IsOpen ...
+func IsOpen() (ok bool) { return }
+
+// Issue ...
+func Issue() error { return nil }
+
+// Hash ...
+func Hash() []byte { return nil }
+
+func shouldStop() bool { return true }
+
+func (t) Is() bool { return true }
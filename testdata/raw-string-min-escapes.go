@@ -0,0 +1,10 @@
+// Test for string literals that could be raw strings, with a lower threshold.
+// CONFIG {"raw-string": true, "raw-string-min-escapes": 2}
+
+// Package foo ...
+package foo
+
+var (
+	two = "a\\b\\c" // MATCH /string literal with 2 escaped backslashes or quotes would be more readable as the raw string `a\\b\\c`/
+	one = "a\\b"
+)
@@ -0,0 +1,16 @@
+// Test for string literals that could be raw strings.
+// CONFIG {"raw-string": true}
+
+// Package foo ...
+package foo
+
+import "regexp"
+
+var (
+	re   = regexp.MustCompile("^\\d+\\.\\d+$") // MATCH /string literal with 3 escaped backslashes or quotes would be more readable as the raw string `\^\\d\+\\\.\\d\+\$`/
+	path = "C:\\Users\\\"me\""                 // MATCH /with 4 escaped/
+	two  = "a\\b\\c"
+	nl   = "a\\b\\c\\d\n"
+	tick = "a\\b\\c\\d`"
+	raw  = `a\b\c\d`
+)
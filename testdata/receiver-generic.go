@@ -0,0 +1,53 @@
+// Test for receiver names of generic types and unusual receivers.
+
+// Package foo ...
+package foo
+
+// Stack is a stack.
+type Stack[T any] struct{}
+
+// Push pushes.
+func (s *Stack[T]) Push(v T) {
+}
+
+// Len returns the length.
+func (s Stack[T]) Len() int {
+	return 0
+}
+
+// Pop pops.
+func (st *Stack[T]) Pop() T { // MATCH /receiver name st should be consistent with previous receiver name s for Stack/
+	var v T
+	return v
+}
+
+// Pair is a pair.
+type Pair[K comparable, V any] struct{}
+
+// Key returns the key.
+func (p *Pair[K, V]) Key() K {
+	var k K
+	return k
+}
+
+// Value returns the value.
+func (this Pair[K, V]) Value() V { // MATCH /should be a reflection of its identity/
+	var v V
+	return v
+}
+
+// Paren has a parenthesized receiver type.
+func (p (Pair[K, V])) Paren() {
+}
+
+// Swap has an unexported receiver type, so needs no comment.
+func (s *stack[T]) Swap() {
+}
+
+type stack[T any] struct{}
+
+func (s, t stack[T]) Two() {
+}
+
+func () None() {
+}
@@ -1,4 +1,5 @@
 // Test for bad receiver names.
+// CONFIG {"use-this": true}
 
 // Package foo ...
 package foo
@@ -0,0 +1,27 @@
+// Test for continue at the end of loop bodies.
+// CONFIG {"redundant-continue": true}
+
+// Package foo ...
+package foo
+
+func f(xs []int) int {
+	n := 0
+	for _, x := range xs {
+		n += x
+		continue // MATCH /continue at the end of a loop body is redundant; remove it/
+	}
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			continue
+		}
+		n--
+	}
+outer:
+	for _, x := range xs {
+		for i := 0; i < x; i++ {
+			n++
+			continue outer
+		}
+	}
+	return n
+}
@@ -0,0 +1,25 @@
+// Test for redundant type conversions with type information.
+// CONFIG {"redundant-conversion": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+type celsius float64
+
+const limit = 10
+
+func f(x int, s string, t celsius, n int64) {
+	a := int(x)       // MATCH /redundant conversion of x to int; it already has that type/
+	b := string(s)    // MATCH /redundant conversion of s to string/
+	c := celsius(t)   // MATCH /redundant conversion of t to celsius/
+	d := int(5)       // MATCH /redundant conversion of 5 to int/
+	e := float64(t)
+	g := int(n)
+	h := int(limit)
+	i := celsius(1.5)
+	_, _, _, _, _, _, _, _ = a, b, c, d, e, g, h, i
+}
+
+func id[T any](v T) T {
+	return T(v) // MATCH /redundant conversion of v to T/
+}
@@ -0,0 +1,18 @@
+// Test for redundant type conversions.
+// CONFIG {"redundant-conversion": true}
+
+// Package foo ...
+package foo
+
+func f(x int, s string) {
+	a := int(5)         // MATCH /redundant conversion of 5 to int; it already has that type/
+	b := string("abc")  // MATCH /redundant conversion of "abc" to string/
+	c := float64(1.5)   // MATCH /redundant conversion of 1.5 to float64/
+	d := int32('a')     // MATCH /redundant conversion of 'a' to int32/
+	e := int64(5)
+	g := float64(5)
+	h := int(x)
+	i := string(s)
+	j := []byte("abc")
+	_, _, _, _, _, _, _, _, _ = a, b, c, d, e, g, h, i, j
+}
@@ -0,0 +1,77 @@
+// Test for labeled break and continue statements where the bare form is equivalent.
+// CONFIG {"redundant-label": true}
+
+// Package foo ...
+package foo
+
+func f(rows [][]int, ch chan int) {
+loop:
+	for _, x := range rows[0] {
+		if x < 0 {
+			continue loop // MATCH /continue loop is redundant: loop labels the innermost statement it applies to; use a bare continue$/
+		}
+		if x > 10 {
+			break loop // MATCH /break loop is redundant/
+		}
+		switch x {
+		case 1:
+			continue loop // MATCH /continue loop is redundant/
+		case 2:
+			break loop
+		}
+	}
+
+outer:
+	for _, row := range rows {
+		for _, x := range row {
+			if x == 0 {
+				continue outer
+			}
+			if x < 0 {
+				break outer
+			}
+		}
+		go func() {
+			for range ch {
+			}
+		}()
+	}
+
+events:
+	for {
+		select {
+		case <-ch:
+			break events
+		default:
+			continue events // MATCH /continue events is redundant/
+		}
+	}
+
+sw:
+	switch len(rows) {
+	case 0:
+		break sw // MATCH /break sw is redundant/
+	case 1:
+		for range rows {
+			break sw
+		}
+	}
+
+only:
+	for range rows {
+		if len(rows) > 1 {
+			continue only // MATCH /continue only is redundant: only labels the innermost statement it applies to; use a bare continue and remove the label, it has no other uses/
+		}
+		break only // MATCH /break only is redundant: .*; use a bare break and remove the label, it has no other uses/
+	}
+
+retry:
+	for range rows {
+		if len(rows) > 1 {
+			continue retry // MATCH /continue retry is redundant: .*; use a bare continue$/
+		}
+	}
+	if len(rows) > 2 {
+		goto retry
+	}
+}
@@ -0,0 +1,25 @@
+// Test for conversions of composite literals to their own type.
+// CONFIG {"redundant-named-conversion": true}
+
+// Package foo ...
+package foo
+
+type foo struct{ N int }
+
+type bar struct{ N int }
+
+type celsius float64
+
+type names []string
+
+func f() {
+	_ = foo(foo{N: 1})       // MATCH /conversion of a foo literal to its own type is redundant/
+	_ = (*foo)(&foo{})       // MATCH /conversion of a foo literal to its own type is redundant/
+	_ = (foo)(foo{})         // MATCH /conversion of a foo literal to its own type is redundant/
+	_ = names(names{"a"})    // MATCH /conversion of a names literal to its own type is redundant/
+	_ = bar(foo{N: 2})       // different named types
+	_ = (*bar)(&foo{})       // different named types
+	_ = celsius(100)         // untyped constant
+	_ = names([]string{"b"}) // different types
+	_ = foo{N: 3}
+}
@@ -0,0 +1,50 @@
+// Test for cleanup calls repeated before returns.
+// CONFIG {"repeated-cleanup": true}
+
+// Package foo ...
+package foo
+
+import "sync"
+
+type conn struct{}
+
+func (conn) Close()         {}
+func (conn) Send(int) error { return nil }
+
+func send(c conn, xs []int) error {
+	for _, x := range xs {
+		if err := c.Send(x); err != nil {
+			c.Close() // MATCH /c.Close\(\) is called before 3 return statements; consider defer c.Close\(\) instead/
+			return err
+		}
+	}
+	if len(xs) == 0 {
+		c.Close()
+		return nil
+	}
+	c.Close()
+	return nil
+}
+
+func lock(mu *sync.Mutex, n int) int {
+	mu.Lock()
+	defer mu.Unlock()
+	if n > 0 {
+		mu.Unlock()
+		return n
+	}
+	mu.Unlock()
+	return 0
+}
+
+func once(c conn, n int) int {
+	if n > 0 {
+		c.Close()
+		return n
+	}
+	f := func() int {
+		c.Close()
+		return 0
+	}
+	return f()
+}
@@ -0,0 +1,19 @@
+// Test for exported functions and types without examples.
+// CONFIG {"require-examples": true, "examples-min-symbols": 3, "min-confidence": 0.3}
+
+// Package foo ...
+package foo
+
+// Open opens.
+func Open() {} // MATCH /exported func Open has no example; consider adding ExampleOpen/
+
+// Close closes.
+func Close() {} // MATCH /exported func Close has no example/
+
+// File is a file.
+type File struct{} // MATCH /exported type File has no example/
+
+// Read reads.
+func (File) Read() {}
+
+func helper() {}
@@ -0,0 +1,9 @@
+// Test for functions returning too many values, not counting a last error.
+// CONFIG {"max-results": 2, "max-results-ignore-error": true}
+
+// Package foo ...
+package foo
+
+func d() (int, int, error) { return 0, 0, nil }
+
+func e() (int, string, int) { return 0, "", 0 } // MATCH /e returns 3 values, more than 2/
@@ -0,0 +1,13 @@
+// Test for functions returning too many values.
+// CONFIG {"max-results": 2}
+
+// Package foo ...
+package foo
+
+func a() (int, int) { return 0, 0 }
+
+func b() (int, string, bool) { return 0, "", false } // MATCH /b returns 3 values, more than 2; consider returning a struct/
+
+func c() (x, y, z int) { return } // MATCH /c returns 3 values/
+
+func d() (int, int, error) { return 0, 0, nil } // MATCH /d returns 3 values/
@@ -0,0 +1,23 @@
+// Test for exported functions returning an interface with one implementation, with type information.
+// CONFIG {"return-interface": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+import "io"
+
+// Source reads things.
+type Source interface {
+	io.Reader
+	Close() error
+}
+
+type file struct{}
+
+func (f *file) Read(p []byte) (int, error) { return 0, nil }
+func (f *file) Close() error               { return nil }
+
+// Open opens a source.
+func Open(name string) (Source, error) { // MATCH /exported Open returns interface Source, which only \*file implements; consider returning \*file/
+	return &file{}, nil
+}
@@ -0,0 +1,46 @@
+// Test for exported functions returning an interface with one implementation.
+// CONFIG {"return-interface": true}
+
+// Package foo ...
+package foo
+
+// Store stores things.
+type Store interface {
+	Get(key string) string
+	Put(key, value string)
+}
+
+type memStore struct{}
+
+func (m *memStore) Get(key string) string { return "" }
+func (m *memStore) Put(key, value string) {}
+
+// NewStore returns a store.
+func NewStore() Store { // MATCH /exported NewStore returns interface Store, which only memStore implements; consider returning memStore/
+	return &memStore{}
+}
+
+// Shape is a shape.
+type Shape interface {
+	Area() float64
+}
+
+type square struct{}
+
+func (square) Area() float64 { return 1 }
+
+type circle struct{}
+
+func (circle) Area() float64 { return 3 }
+
+// NewShape returns a shape.
+func NewShape(round bool) Shape {
+	if round {
+		return circle{}
+	}
+	return square{}
+}
+
+func newStore() Store {
+	return &memStore{}
+}
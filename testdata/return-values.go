@@ -1,4 +1,5 @@
 // Test that return values has no names
+// CONFIG {"named-return": true}
 
 // Package foo ...
 package foo
@@ -7,14 +8,17 @@ func f1() (x int) { // MATCH /return value.*should not be named/
 	return 0
 }
 
+// MATCH:12 /return value #1\("y"\) should not be named/
 func f2() (x, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
 
+// MATCH:17 /return value #1\("y"\) should not be named/
 func f3() (x int, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
 
+// MATCH:22 /return value #1\("y"\) should not be named/
 func f4() (int, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
@@ -25,14 +29,17 @@ func (r ret) f5() (x int) { // MATCH /return value.*should not be named/
 	return 0
 }
 
+// MATCH:33 /return value #1\("y"\) should not be named/
 func (r ret) f6() (x, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
 
+// MATCH:38 /return value #1\("y"\) should not be named/
 func (r ret) f7() (x int, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
 
+// MATCH:43 /return value #1\("y"\) should not be named/
 func (r ret) f8() (int, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
@@ -41,6 +48,21 @@ func (r ret) f9() (int int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
 
+// MATCH:52 /return value #1\("y"\) should not be named/
 func (r ret) f10() (int, y int) { // MATCH /return value.*should not be named/
 	return 0, 0
 }
+
+// MATCH:58 /return value #1\("m"\) should not be named/
+// MATCH:58 /return value #2\("extra"\) should not be named/
+func f11() (n, m int, extra string) { // MATCH /return value #0\("n"\) should not be named/
+	return 0, 0, ""
+}
+
+func f12() (_, _ int, err error) { // MATCH /return value #2\("err"\) should not be named/
+	return 0, 0, nil
+}
+
+func f13() (_ int, _ error) {
+	return 0, nil
+}
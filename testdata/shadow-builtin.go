@@ -0,0 +1,13 @@
+// Test for declarations shadowing builtins, reported at the default confidence.
+// CONFIG {"shadow-builtin": true, "builtin-shadow": true, "min-confidence": 0.8}
+
+// Package foo ...
+package foo
+
+func f(len int) { // MATCH /parameter len shadows the builtin function len/
+	string := "x" // MATCH /var string shadows the builtin type string/
+	_ = string
+	for _, new := range []int{len} { // MATCH /range var new shadows the builtin function new/
+		_ = new
+	}
+}
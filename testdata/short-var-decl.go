@@ -0,0 +1,23 @@
+// Test for var declarations that could be short variable declarations.
+// CONFIG {"short-var-decl": true}
+
+// Package foo ...
+package foo
+
+import "io"
+
+var global = 5
+
+func f(r io.Reader) int {
+	var x = 5 // MATCH /should use the short variable declaration x := 5 instead of var/
+	var n int64 = 5 // MATCH /should use the short variable declaration n := int64\(5\) instead of var/
+	var a, b = "a", r // MATCH /a, b := "a", r/
+	var c interface{} = 1
+	var (
+		y = 1
+	)
+	var z int
+	var _ = x
+	_, _, _, _, _ = c, y, z, a, b
+	return x + int(n)
+}
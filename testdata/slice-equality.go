@@ -0,0 +1,23 @@
+// Test for comparisons of slices and maps.
+// CONFIG {"slice-equality": true}
+
+// Package foo ...
+package foo
+
+func f(a, b []int, m map[string]int) bool {
+	if a == nil || m != nil {
+		return false
+	}
+	if a == b { // MATCH /slices can only be compared to nil; use slices.Equal or reflect.DeepEqual to compare a and b/
+		return true
+	}
+	s := []string{"x"}
+	if s != []string{"x"} { // MATCH /slices can only be compared to nil; use slices.Equal/
+		return true
+	}
+	if m == map[string]int{} { // MATCH /maps can only be compared to nil; use maps.Equal/
+		return true
+	}
+	var x, y [2]int
+	return x == y
+}
@@ -0,0 +1,31 @@
+// Test for map keys built with fmt.Sprintf in loops.
+// CONFIG {"sprintf-key": true, "min-confidence": 0.3}
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+type pair struct{ a, b int }
+
+func f(m map[string]int, ids []int) {
+	for _, id := range ids {
+		m[fmt.Sprintf("%d-%d", id, id)]++ // MATCH /map key built with fmt.Sprintf in a loop; consider a struct key or building it with strconv/
+	}
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			_ = m[fmt.Sprintf("%d:%d", i, j)] // MATCH /map key built with fmt.Sprintf in a loop/
+		}
+	}
+	for _, id := range ids {
+		key := fmt.Sprintf("%d", id)
+		_ = m[key]
+		_ = fmt.Sprint(m[key])
+	}
+	_ = m[fmt.Sprintf("%d", 1)]
+
+	pm := make(map[pair]int)
+	for _, id := range ids {
+		pm[pair{id, id}]++
+	}
+}
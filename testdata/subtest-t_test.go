@@ -0,0 +1,24 @@
+// Test for subtests using the outer *testing.T.
+// CONFIG {"subtest-t": true}
+
+package foo
+
+import "testing"
+
+func TestRun(t *testing.T) {
+	t.Run("outer", func(st *testing.T) {
+		t.Fatal("boom") // MATCH /subtest uses the outer t instead of its own parameter st/
+	})
+	t.Run("own", func(st *testing.T) {
+		st.Fatal("boom")
+	})
+	t.Run("shadowed", func(t *testing.T) {
+		t.Fatal("boom")
+	})
+}
+
+func BenchmarkRun(b *testing.B) {
+	b.Run("outer", func(sb *testing.B) {
+		helper(b) // MATCH /subtest uses the outer b instead of its own parameter sb/
+	})
+}
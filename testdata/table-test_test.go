@@ -0,0 +1,32 @@
+// Test for table-driven tests without subtests.
+// CONFIG {"table-test": true}
+
+package foo
+
+import "testing"
+
+func TestTable(t *testing.T) {
+	tests := []struct{ in, want int }{{1, 1}}
+	for _, tt := range tests { // MATCH /table-driven test loop over tests doesn't use t.Run/
+		if got := tt.in; got != tt.want {
+			t.Errorf("got %d, want %d", got, tt.want)
+		}
+	}
+	for _, tc := range testCases { // MATCH /loop over testCases/
+		_ = tc
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			_ = tt
+		})
+	}
+	for _, x := range values {
+		_ = x
+	}
+}
+
+func helper(t *testing.T) {
+	for _, tt := range tests {
+		_ = tt
+	}
+}
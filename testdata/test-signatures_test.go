@@ -0,0 +1,32 @@
+// Test for signatures of test functions.
+// CONFIG {"test-signatures": true}
+
+package foo
+
+import "testing"
+
+func TestMain(m *testing.M) {}
+
+func TestGood(t *testing.T) {}
+
+func Test(t *testing.T) {}
+
+func Test_underscore(t *testing.T) {}
+
+func Testfoo(t *testing.T) {} // MATCH /Testfoo is not run by go test because the character after Test is lowercase/
+
+func TestWrongArg(b *testing.B) {} // MATCH /TestWrongArg should have signature func\(\*testing.T\)/
+
+func TestResult(t *testing.T) error { return nil } // MATCH /TestResult should have signature/
+
+func BenchmarkGood(b *testing.B) {}
+
+func Benchmarkfoo(b *testing.B) {} // MATCH /Benchmarkfoo is not run by go test/
+
+func BenchmarkWrongArg(t *testing.T) {} // MATCH /BenchmarkWrongArg should have signature func\(\*testing.B\)/
+
+func ExampleGood() {}
+
+func ExampleParams(x int) {} // MATCH /example function ExampleParams should have no parameters and no results/
+
+func helper(t *testing.T) {}
@@ -0,0 +1,31 @@
+// Test for exported functions that only forward to an unexported one.
+// CONFIG {"thin-wrapper": true}
+
+// Package foo ...
+package foo
+
+// Parse parses s.
+func Parse(s string, strict bool) (int, error) { // MATCH /exported Parse only calls parse, which has the same signature; consider merging them, or document what the wrapper is for/
+	return parse(s, strict)
+}
+
+func parse(s string, strict bool) (int, error) { return 0, nil }
+
+// Join joins parts.
+func Join(sep string, parts ...string) string { // MATCH /exported Join only calls join/
+	return join(sep, parts...)
+}
+
+func join(sep string, parts ...string) string { return "" }
+
+// Lenient parses s leniently.
+func Lenient(s string) (int, error) {
+	return parse(s, false)
+}
+
+// Swap swaps its arguments.
+func Swap(a, b string) string {
+	return concat(b, a)
+}
+
+func concat(a, b string) string { return a + b }
@@ -0,0 +1,30 @@
+// Test for loop bodies ending with break.
+// CONFIG {"trailing-break": true}
+
+// Package foo ...
+package foo
+
+func f(xs []int, ch chan int) int {
+	n := 0
+	for _, x := range xs { // MATCH /loop body ends with a break at line 11, so the loop runs at most once/
+		n += x
+		break
+	}
+	for i := 0; i < n; i++ {
+		switch i {
+		case 1:
+			n--
+			break
+		}
+	}
+	for {
+		select {
+		case x := <-ch:
+			n += x
+		}
+		if n > 10 {
+			break
+		}
+	}
+	return n
+}
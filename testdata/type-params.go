@@ -0,0 +1,28 @@
+// Test for generic declarations with too many type parameters.
+// CONFIG {"max-type-params": 2, "min-confidence": 0.3}
+
+// Package foo ...
+package foo
+
+// Pair is a pair.
+type Pair[K comparable, V any] struct{}
+
+// Triple is a triple.
+type Triple[A, B, C any] struct{} // MATCH /type Triple has 3 type parameters, more than 2/
+
+// Plain isn't generic.
+type Plain struct{}
+
+// Zip zips.
+func Zip[A, B any](a []A, b []B) {
+}
+
+// Reduce reduces.
+func Reduce[S ~[]E, E any, R any](s S, fn func(R, E) R) (r R) { // MATCH /func Reduce has 3 type parameters, more than 2/
+	return r
+}
+
+// Get gets.
+func (p Pair[K, V]) Get(k K) (v V) {
+	return v
+}
@@ -0,0 +1,35 @@
+// Test for unexported sentinel errors wrapped by exported functions.
+// CONFIG {"unexported-sentinel": true}
+
+// Package foo ...
+package foo
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errNotFound = errors.New("not found")
+
+// ErrClosed is returned when closed.
+var ErrClosed = errors.New("closed")
+
+// Open opens things.
+func Open(name string) error {
+	if name == "" {
+		return fmt.Errorf("open %q: %w", name, errNotFound) // MATCH /exported Open returns an error wrapping unexported error errNotFound; callers can't check for it with errors.Is/
+	}
+	if name == "closed" {
+		return fmt.Errorf("open %q: %w", name, ErrClosed)
+	}
+	if name == "direct" {
+		return errNotFound
+	}
+	f := func() error { return fmt.Errorf("open: %w", errNotFound) }
+	_ = f
+	return fmt.Errorf("open %q: %v", name, errNotFound)
+}
+
+func open() error {
+	return fmt.Errorf("open: %w", errNotFound)
+}
@@ -0,0 +1,39 @@
+// Test for functions that don't use their context parameter.
+// CONFIG {"unused-context": true}
+
+// Package foo ...
+package foo
+
+import "context"
+
+type fetcher interface {
+	Fetch(ctx context.Context, key string) string
+}
+
+type cache struct{}
+
+func (c *cache) Fetch(ctx context.Context, key string) string {
+	return key
+}
+
+func (c *cache) Store(ctx context.Context, key string) { // MATCH /context parameter ctx is not used in Store; pass it on or name it _ if it is not needed/
+}
+
+func get(ctx context.Context, key string) string { // MATCH /context parameter ctx is not used in get/
+	return key
+}
+
+func run(ctx context.Context) error {
+	return do(ctx)
+}
+
+func do(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func ignore(_ context.Context, c context.Context) {
+	go func() {
+		_ = c
+	}()
+}
@@ -0,0 +1,89 @@
+// Test for errors that are made but never used.
+// CONFIG {"unused-error": true}
+
+// Package foo ...
+package foo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+func validate(n int) error { return nil }
+
+func overwritten() error {
+	err := errors.New("a") // MATCH /error assigned to err is overwritten before it is used; return or handle it, or remove it/
+	err = errors.New("b")
+	return err
+}
+
+func notReturned(n int) error {
+	err := validate(n)
+	if err != nil {
+		return err
+	}
+	err = fmt.Errorf("n = %d", n) // MATCH /error assigned to err is never used; return or handle it, or remove it/
+	return nil
+}
+
+func redeclared(s string) (int, error) {
+	err := errors.New("empty") // MATCH /error assigned to err is overwritten before it is used/
+	n, err := strconv.Atoi(s)
+	return n, err
+}
+
+func returned(n int) error {
+	err := errors.New("bad")
+	if n > 0 {
+		return err
+	}
+	e := fmt.Errorf("n = %d", n)
+	log.Print(e)
+	return nil
+}
+
+func conditional(n int) error {
+	err := errors.New("default")
+	if n > 0 {
+		err = fmt.Errorf("n = %d", n)
+	}
+	return err
+}
+
+func wrapped(n int) error {
+	err := errors.New("bad")
+	err = fmt.Errorf("n = %d: %w", n, err)
+	return err
+}
+
+func loop(items []int) error {
+	var err error
+	for _, it := range items {
+		if err != nil {
+			return err
+		}
+		err = fmt.Errorf("item %d", it)
+	}
+	return nil
+}
+
+func named() (err error) {
+	err = errors.New("bad")
+	return
+}
+
+func deferred() {
+	err := errors.New("a")
+	defer func() { log.Print(err) }()
+	err = errors.New("b")
+}
+
+func pointer() error {
+	err := errors.New("a")
+	set(&err)
+	return err
+}
+
+func set(err *error) {}
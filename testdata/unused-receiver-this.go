@@ -0,0 +1,17 @@
+// Test for named receivers that are not used, with use-this.
+// CONFIG {"unused-receiver": true, "use-this": true}
+
+// Package foo ...
+package foo
+
+// T is a thing.
+type T struct{ n int }
+
+// N returns n.
+func (this *T) N() int { return this.n }
+
+// Zero returns zero.
+func (this *T) Zero() int { return 0 } // MATCH /receiver this is not used in method Zero; omit the receiver name/
+
+// One returns one.
+func (*T) One() int { return 1 }
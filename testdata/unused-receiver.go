@@ -0,0 +1,24 @@
+// Test for named receivers that are not used.
+// CONFIG {"unused-receiver": true}
+
+// Package foo ...
+package foo
+
+// T is a thing.
+type T struct{ n int }
+
+// N returns n.
+func (t *T) N() int { return t.n }
+
+// Zero returns zero.
+func (t *T) Zero() int { return 0 } // MATCH /receiver t is not used in method Zero; omit the receiver name/
+
+// One returns one.
+func (*T) One() int { return 1 }
+
+func (t *T) unexported() int { return 2 }
+
+// Closure uses the receiver in a func literal.
+func (t *T) Closure() func() int {
+	return func() int { return t.n }
+}
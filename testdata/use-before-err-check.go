@@ -0,0 +1,34 @@
+// Test for results used before the error returned with them is checked.
+// CONFIG {"use-before-err-check": true}
+
+// Package foo ...
+package foo
+
+import (
+	"fmt"
+	"os"
+)
+
+func f(name string) error {
+	fi, err := os.Stat(name)
+	fmt.Println(fi.Size()) // MATCH /fi is used before err is checked; it may not be valid if there was an error/
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	n, err := file.Read(nil)
+	total := 0
+	if err != nil {
+		return err
+	}
+	total += n
+
+	m, readErr := file.Read(nil)
+	return fmt.Errorf("read %d: %v", m, readErr)
+}
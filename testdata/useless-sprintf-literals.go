@@ -0,0 +1,27 @@
+// Test for fmt.Sprintf calls that could be simple conversions, without type information.
+// CONFIG {"useless-sprintf": true}
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+func use(string) {}
+
+func f(s string, n int) {
+	use(fmt.Sprintf("%d", 42))   // MATCH /should replace fmt.Sprintf\("%d", 42\) with strconv.Itoa\(42\)/
+	use(fmt.Sprintf("%s", "x"))  // MATCH /with "x"$/
+	use(fmt.Sprintf("%q", "x"))  // MATCH /with strconv.Quote\("x"\)/
+	use(fmt.Sprintf("%c", 'x'))  // MATCH /with string\('x'\)/
+	use(fmt.Sprintf("%t", true)) // MATCH /with strconv.FormatBool\(true\)/
+	use(fmt.Sprintf("%s", s))
+	use(fmt.Sprintf("%d", n))
+	use(fmt.Sprintf("%d", 1.5))
+	use(fmt.Sprintf("%s", 42))
+	use(fmt.Sprintf("%c", 42))
+	use(fmt.Sprintf("%d", 'x'))
+}
+
+func g(true bool) {
+	use(fmt.Sprintf("%t", true))
+}
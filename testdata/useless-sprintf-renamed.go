@@ -0,0 +1,11 @@
+// Test for fmt.Sprintf calls that could be simple conversions, with fmt renamed.
+// CONFIG {"useless-sprintf": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+import format "fmt"
+
+func f(n int) string {
+	return format.Sprintf("%d", n) // MATCH /should replace format.Sprintf\("%d", n\) with strconv.Itoa\(n\)/
+}
@@ -0,0 +1,29 @@
+// Test for fmt.Sprintf calls that could be simple conversions.
+// CONFIG {"useless-sprintf": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+type name string
+
+func use(string) {}
+
+func f(s string, n int, b bool, r rune, n64 int64, u uint, nm name) {
+	use(fmt.Sprintf("%s", s))  // MATCH /should replace fmt.Sprintf\("%s", s\) with s$/
+	use(fmt.Sprintf("%d", n))  // MATCH /should replace fmt.Sprintf\("%d", n\) with strconv.Itoa\(n\)/
+	use(fmt.Sprintf("%t", b))  // MATCH /with strconv.FormatBool\(b\)/
+	use(fmt.Sprintf(`%q`, s))  // MATCH /with strconv.Quote\(s\)/
+	use(fmt.Sprintf("%c", r))  // MATCH /with string\(r\)/
+	use(fmt.Sprintf("%d", 42)) // MATCH /with strconv.Itoa\(42\)/
+	use(fmt.Sprintf("%d", n64))
+	use(fmt.Sprintf("%d", u))
+	use(fmt.Sprintf("%q", r))
+	use(fmt.Sprintf("%q", n))
+	use(fmt.Sprintf("%s", nm))
+	use(fmt.Sprintf("%v", n))
+	use(fmt.Sprintf("%d%s", n, s))
+	use(fmt.Sprintf("n=%d", n))
+	use(fmt.Sprintf("%d", n, n))
+}
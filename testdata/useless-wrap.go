@@ -0,0 +1,28 @@
+// Test for fmt.Errorf calls that add no context.
+// CONFIG {"useless-wrap": true}
+
+// Package foo ...
+package foo
+
+import (
+	"fmt"
+	"os"
+)
+
+func f(name string) error {
+	err := os.Remove(name)
+	if err != nil {
+		return fmt.Errorf("%w", err) // MATCH /fmt.Errorf\("%w", err\) adds no context; return err directly or add context/
+	}
+	var closeErr error
+	if closeErr != nil {
+		return fmt.Errorf(`%v`, closeErr) // MATCH /fmt.Errorf\(`%v`, closeErr\) adds no context/
+	}
+	if err != nil {
+		return fmt.Errorf("remove %s: %w", name, err)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: done", err)
+	}
+	return fmt.Errorf("%s", name)
+}
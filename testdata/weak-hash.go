@@ -0,0 +1,25 @@
+// Test for weak hashes used for integrity or authentication.
+// CONFIG {"weak-hash": true}
+
+// Package foo ...
+package foo
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+)
+
+func signMessage(key, msg []byte) []byte {
+	mac := hmac.New(sha1.New, key) // MATCH /weak hash SHA-1 \(sha1.New\) used in signMessage; use SHA-256/
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+func hashPassword(p string) [16]byte {
+	return md5.Sum([]byte(p)) // MATCH /weak hash MD5 \(md5.Sum\) used in hashPassword/
+}
+
+func fileChecksum(b []byte) [16]byte {
+	return md5.Sum(b)
+}
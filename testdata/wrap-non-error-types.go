@@ -0,0 +1,25 @@
+// Test for %w verbs with arguments that are not errors, with type information.
+// CONFIG {"wrap-non-error": true, "type-check": true}
+
+// Package foo ...
+package foo
+
+import "fmt"
+
+type code int
+
+type codeError struct{}
+
+func (codeError) Error() string { return "code" }
+
+func lookup() code { return 0 }
+
+func f(err error, v interface{}) error {
+	if err == nil {
+		return fmt.Errorf("lookup: %w", lookup()) // MATCH /but lookup\(\) is of type foo.code; use %v instead/
+	}
+	if v == nil {
+		return fmt.Errorf("%w", codeError{})
+	}
+	return fmt.Errorf("%w: %w", err, v)
+}
@@ -0,0 +1,26 @@
+// Test for %w verbs with arguments that are not errors.
+// CONFIG {"wrap-non-error": true}
+
+// Package foo ...
+package foo
+
+import (
+	"errors"
+	"fmt"
+)
+
+func f(name string, err error, n int) error {
+	if n == 0 {
+		return fmt.Errorf("open %s: %w", name, "failed") // MATCH /%w in fmt.Errorf needs an error, but "failed" is of type string; use %v instead/
+	}
+	if n == 1 {
+		return fmt.Errorf("open %*d: %w", 3, n, name) // MATCH /but name is of type string/
+	}
+	if n == 2 {
+		return fmt.Errorf("%d%%: %w", n, n) // MATCH /but n is of type int/
+	}
+	if n == 3 {
+		return fmt.Errorf("%[2]s: %[1]w", err, name)
+	}
+	return fmt.Errorf("open %s: %w", name, errors.Join(err))
+}